@@ -4,7 +4,11 @@
 
 package action
 
-import "context"
+import (
+	"context"
+
+	"github.com/raohwork/task"
+)
 
 // Default wraps d to provide default value whenever it failed.
 func (d Data[T]) Default(v T) Data[T] {
@@ -32,8 +36,8 @@ func (d Data[T]) DefaultIfNot(errf func(error) bool, v T) Data[T] {
 // Retry wraps d to run it repeatly until success.
 func (d Data[T]) Retry() Data[T] {
 	return func(ctx context.Context) (ret T, err error) {
-		for {
-			ret, err = d(ctx)
+		for i := 1; ; i++ {
+			ret, err = d(task.WithAttempt(ctx, i))
 			if err == nil {
 				return
 			}
@@ -50,7 +54,7 @@ func (d Data[T]) RetryN(n int) Data[T] {
 	}
 	return func(ctx context.Context) (ret T, err error) {
 		for x := 0; x <= n; x++ {
-			ret, err = d(ctx)
+			ret, err = d(task.WithAttempt(ctx, x+1))
 			if err == nil {
 				return
 			}
@@ -64,8 +68,8 @@ func (d Data[T]) RetryN(n int) Data[T] {
 // Error passed to errf will never be nil.
 func (d Data[T]) RetryIf(errf func(error) bool) Data[T] {
 	return func(ctx context.Context) (ret T, err error) {
-		for {
-			ret, err = d(ctx)
+		for i := 1; ; i++ {
+			ret, err = d(task.WithAttempt(ctx, i))
 			if err == nil || !errf(err) {
 				return
 			}
@@ -82,7 +86,7 @@ func (d Data[T]) RetryNIf(n int, errf func(error) bool) Data[T] {
 	}
 	return func(ctx context.Context) (ret T, err error) {
 		for x := 0; x <= n; x++ {
-			ret, err = d(ctx)
+			ret, err = d(task.WithAttempt(ctx, x+1))
 			if err == nil || !errf(err) {
 				return
 			}
@@ -90,3 +94,15 @@ func (d Data[T]) RetryNIf(n int, errf func(error) bool) Data[T] {
 		return
 	}
 }
+
+// RetryWith wraps d to run it repeatly until success, waiting between attempts as
+// computed by p. See [task.Task.RetryWith] for detail.
+func (d Data[T]) RetryWith(p task.BackoffPolicy) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		err = task.Task(func(ctx context.Context) (e error) {
+			ret, e = d(ctx)
+			return
+		}).RetryWith(p).Run(ctx)
+		return
+	}
+}
@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// Hedge wraps d to run duplicates of it if it is slow, and uses first successful
+// result. See [task.Task.Hedge] for detail.
+func (d Data[T]) Hedge(delay time.Duration, maxExtra int) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		var (
+			lock sync.Mutex
+			done bool
+		)
+		err = task.Task(func(ctx context.Context) error {
+			v, err := d(ctx)
+			if err != nil {
+				return err
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			if !done {
+				ret, done = v, true
+			}
+			return nil
+		}).Hedge(delay, maxExtra).Run(ctx)
+		return
+	}
+}
@@ -0,0 +1,43 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+
+	"github.com/raohwork/task"
+)
+
+// PollError is returned by [Data.PollUntil] if it exceeds the limits before the
+// condition is met. It wraps [task.ErrLoopLimit].
+type PollError[T any] struct {
+	// Last is the value returned by last run.
+	Last T
+}
+
+func (e PollError[T]) Error() string { return "polling: " + task.ErrLoopLimit.Error() }
+func (e PollError[T]) Unwrap() error { return task.ErrLoopLimit }
+
+// PollUntil wraps d to run it repeatly until pred returns true, and returns the
+// value. See [task.Task.LoopUntil] for how opt works.
+//
+// It stops if d returns an error. A [PollError] is returned if it exceeds the limits
+// in opt.
+func (d Data[T]) PollUntil(pred func(T) bool, opt task.LoopOptions) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		done := false
+		err = task.Task(func(ctx context.Context) (e error) {
+			ret, e = d(ctx)
+			if e == nil {
+				done = pred(ret)
+			}
+			return
+		}).LoopUntil(func() bool { return done }, opt).Run(ctx)
+		if err == task.ErrLoopLimit {
+			err = PollError[T]{Last: ret}
+		}
+		return
+	}
+}
@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+func ExampleData_PollUntil() {
+	n := 0
+	status := NoErrUse(func() string {
+		n++
+		if n < 3 {
+			return "RUNNING"
+		}
+		return "DONE"
+	})
+	isDone := func(s string) bool { return s == "DONE" }
+	opt := task.LoopOptions{MaxIterations: 5, Interval: time.Millisecond}
+
+	fmt.Println(status.PollUntil(isDone, opt).Get(context.Background()))
+
+	n = -10
+	_, err := status.PollUntil(isDone, opt).Get(context.Background())
+	var pe PollError[string]
+	if errors.As(err, &pe) {
+		fmt.Println("last status:", pe.Last)
+	}
+	fmt.Println(errors.Is(err, task.ErrLoopLimit))
+
+	// output: DONE <nil>
+	// last status: RUNNING
+	// true
+}
@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"sync"
+
+	"github.com/raohwork/task"
+)
+
+// QuorumData creates a Data that runs ds concurrently, and returns values of first
+// k successful ones in the order they finished. See [task.Quorum] for how others
+// are canceled and how errors are reported.
+//
+// Take care of Data created by [NoCtxUse] and [NoErrUse] as it cannot be
+// cancelled by context.
+func QuorumData[T any](k int, ds ...Data[T]) Data[[]T] {
+	return func(ctx context.Context) ([]T, error) {
+		var (
+			lock sync.Mutex
+			ret  []T
+		)
+		tasks := make([]task.Task, len(ds))
+		for idx, d := range ds {
+			d := d
+			tasks[idx] = func(ctx context.Context) error {
+				v, err := d(ctx)
+				if err != nil {
+					return err
+				}
+
+				lock.Lock()
+				defer lock.Unlock()
+				if len(ret) < k {
+					ret = append(ret, v)
+				}
+				return nil
+			}
+		}
+
+		if err := task.Quorum(k, tasks...).Run(ctx); err != nil {
+			return nil, err
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		return ret, nil
+	}
+}
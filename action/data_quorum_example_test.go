@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func ExampleQuorumData() {
+	replica := func(v int, err error) Data[int] {
+		return func(_ context.Context) (int, error) { return v, err }
+	}
+	errDown := errors.New("replica is down")
+
+	vals, err := QuorumData(2,
+		replica(1, nil),
+		replica(0, errDown),
+		replica(1, nil),
+	).Get(context.Background())
+	fmt.Println(vals, err)
+
+	_, err = QuorumData(2,
+		replica(1, nil),
+		replica(0, errDown),
+		replica(0, errDown),
+	).Get(context.Background())
+	fmt.Println(err)
+
+	// output: [1 1] <nil>
+	// 2 tasks failed: task #1: replica is down; task #2: replica is down
+}
@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/raohwork/task"
+)
+
+// ErrNoSource is returned by [Race] and [FirstOf] if no Data is given.
+var ErrNoSource = errors.New("no data source is given")
+
+// Race creates a Data that runs ds concurrently, and returns first successful
+// value. Others are canceled with [task.ErrOneHasDone] as cancel cause.
+//
+// If all of them failed, a [task.MultiError] is returned.
+//
+// Take care of Data created by [NoCtxUse] and [NoErrUse] as it cannot be
+// cancelled by context.
+func Race[T any](ds ...Data[T]) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		if len(ds) == 0 {
+			return ret, ErrNoSource
+		}
+
+		var (
+			lock sync.Mutex
+			errs task.MultiError
+			won  = make(chan struct{})
+			lost = make(chan struct{})
+		)
+		// failed ones wait until any succeeded or all failed, so First returns
+		// first successful value
+		tasks := make([]task.Task, len(ds))
+		for idx, d := range ds {
+			idx, d := idx, d
+			if task.RecoverByDefault() {
+				d = d.Recover()
+			}
+			tasks[idx] = func(ctx context.Context) error {
+				v, err := d(ctx)
+
+				lock.Lock()
+				if err == nil {
+					select {
+					case <-won:
+					default:
+						ret = v
+						close(won)
+					}
+					lock.Unlock()
+					return nil
+				}
+				errs = append(errs, task.TaskError{Index: idx, Err: err})
+				if len(errs) == len(ds) {
+					close(lost)
+				}
+				lock.Unlock()
+
+				select {
+				case <-won:
+				case <-lost:
+				}
+				return err
+			}
+		}
+
+		task.First(tasks...).Run(ctx)
+
+		lock.Lock()
+		defer lock.Unlock()
+		select {
+		case <-won:
+			return ret, nil
+		default:
+		}
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+		var zero T
+		return zero, errs
+	}
+}
+
+// FirstOf creates a Data that tries ds one by one, and returns first successful
+// value. It's useful to implement fallbacks like "cache -> replica -> origin".
+//
+// If all of them failed, a [task.MultiError] is returned. It stops trying if the
+// context is canceled.
+func FirstOf[T any](ds ...Data[T]) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		if len(ds) == 0 {
+			return ret, ErrNoSource
+		}
+
+		var errs task.MultiError
+		for idx, d := range ds {
+			if idx > 0 && ctx.Err() != nil {
+				break
+			}
+			if ret, err = d(ctx); err == nil {
+				return
+			}
+			errs = append(errs, task.TaskError{Index: idx, Err: err})
+		}
+		return ret, errs
+	}
+}
@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+func ExampleRace() {
+	slow := Use(func(ctx context.Context) (string, error) {
+		if err := task.Sleep(time.Second).Run(ctx); err != nil {
+			fmt.Println("slow:", context.Cause(ctx))
+			return "", err
+		}
+		return "slow", nil
+	})
+	fail := UseError[string](errors.New("fail"))
+	fast := Use(func(ctx context.Context) (string, error) {
+		task.Sleep(10 * time.Millisecond).Run(ctx)
+		return "fast", nil
+	})
+
+	fmt.Println(Race(slow, fail, fast).Get(context.Background()))
+	time.Sleep(10 * time.Millisecond) // wait slow to print
+	_, err := Race(fail, fail).Get(context.Background())
+	fmt.Println(err)
+
+	// output: fast <nil>
+	// slow: another task has been done
+	// 2 tasks failed: task #0: fail; task #1: fail
+}
+
+func ExampleFirstOf() {
+	cache := UseError[string](errors.New("cache miss"))
+	replica := Use(func(_ context.Context) (string, error) {
+		fmt.Println("read replica")
+		return "value", nil
+	})
+	origin := Use(func(_ context.Context) (string, error) {
+		fmt.Println("read origin")
+		return "value", nil
+	})
+
+	fmt.Println(FirstOf(cache, replica, origin).Get(context.Background()))
+
+	// output: read replica
+	// value <nil>
+}
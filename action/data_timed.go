@@ -13,9 +13,10 @@ import (
 
 func (d Data[T]) timed(dur func(time.Duration) time.Duration, e func(error) bool) Data[T] {
 	return func(ctx context.Context) (ret T, err error) {
-		begin := time.Now()
+		clock := task.ClockFrom(ctx)
+		begin := clock.Now()
 		ret, err = d(ctx)
-		wait := dur(time.Since(begin))
+		wait := dur(clock.Since(begin))
 		if wait > 0 && e(err) {
 			er := task.Sleep(wait)(ctx)
 			if err == nil {
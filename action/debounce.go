@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/internal/coalesce"
+)
+
+// Debouncer coalesces bursts of calls into one execution. See [Action.Debounce].
+type Debouncer[T any] struct {
+	d *coalesce.Debouncer[T]
+}
+
+// Debounce is like [task.Task.Debounce], the execution uses the value passed by
+// latest caller.
+func (a Action[T]) Debounce(wait, maxWait time.Duration) *Debouncer[T] {
+	return &Debouncer[T]{coalesce.NewDebouncer(a.recoverIfDefault, wait, maxWait, coalesceClock)}
+}
+
+// coalesceClock retrieves the clock from ctx for package coalesce.
+func coalesceClock(ctx context.Context) coalesce.Clock {
+	return coalesce.Wrap[task.Timer](task.ClockFrom(ctx))
+}
+
+// recoverIfDefault runs a, recovers from panic if [task.RecoverByDefault] is
+// enabled.
+func (a Action[T]) recoverIfDefault(ctx context.Context, v T) error {
+	if task.RecoverByDefault() {
+		return a.Recover()(ctx, v)
+	}
+	return a(ctx, v)
+}
+
+// Action creates an Action which calls the debounced action.
+func (d *Debouncer[T]) Action() Action[T] { return d.d.Call }
+
+// Flush executes pending execution immediately, and returns its result. It
+// returns nil if nothing is pending.
+func (d *Debouncer[T]) Flush() error { return d.d.Flush() }
+
+// Cancel drops pending execution. Callers waiting for it receive
+// [task.ErrDropped].
+func (d *Debouncer[T]) Cancel() { d.d.Cancel() }
+
+// Throttler limits an action to run no more than once per interval. See
+// [Action.Throttle].
+type Throttler[T any] struct {
+	t *coalesce.Throttler[T]
+}
+
+// Throttle is like [task.Task.Throttle], trailing execution uses the value passed
+// by latest caller.
+func (a Action[T]) Throttle(interval time.Duration, leading, trailing bool) *Throttler[T] {
+	return &Throttler[T]{coalesce.NewThrottler(a.recoverIfDefault, interval, leading, trailing, coalesceClock)}
+}
+
+// Action creates an Action which calls the throttled action.
+func (t *Throttler[T]) Action() Action[T] { return t.t.Call }
+
+// Flush executes pending trailing execution immediately, and returns its result.
+// It returns nil if nothing is pending.
+func (t *Throttler[T]) Flush() error { return t.t.Flush() }
+
+// Cancel drops pending trailing execution. Callers waiting for it receive
+// [task.ErrDropped].
+func (t *Throttler[T]) Cancel() { t.t.Cancel() }
@@ -0,0 +1,37 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/raohwork/task"
+)
+
+// Logged wraps d to log when it begins and finishes. See [task.Task.Logged].
+func (d Data[T]) Logged(logger *slog.Logger, name string, opts ...task.LogOption) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		err = task.Task(func(ctx context.Context) (e error) {
+			ret, e = d(ctx)
+			return
+		}).Logged(logger, name, opts...).Run(ctx)
+		return
+	}
+}
+
+// Logged wraps a to log when it begins and finishes. See [task.Task.Logged].
+func (a Action[T]) Logged(logger *slog.Logger, name string, opts ...task.LogOption) Action[T] {
+	return func(ctx context.Context, v T) error {
+		return a.Apply(v).Logged(logger, name, opts...).Run(ctx)
+	}
+}
+
+// Logged wraps c to log when it begins and finishes. See [task.Task.Logged].
+func (c Converter[I, O]) Logged(logger *slog.Logger, name string, opts ...task.LogOption) Converter[I, O] {
+	return func(ctx context.Context, i I) (O, error) {
+		return c.By(i).Logged(logger, name, opts...).Get(ctx)
+	}
+}
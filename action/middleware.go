@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/raohwork/task"
+)
+
+var (
+	// ErrDetached is returned by an Action or Converter wrapped by middleware, if
+	// the middleware runs it with a context not derived from the one passed to
+	// it, so the input is lost.
+	ErrDetached = errors.New("input is lost as the middleware detached the context")
+	// ErrNoResult is returned by a function wrapped by middleware, if the
+	// middleware succeeds without successfully running it, like [task.Task.Cached]
+	// returning cached result, or detaching the context.
+	ErrNoResult = errors.New("middleware returned without a result")
+)
+
+// slotKey is the context key to pass value between wrapped function and the task
+// wrapped by middleware. It is not zero-sized, so every key is unique.
+type slotKey struct{ _ byte }
+
+// slot saves first successful output.
+type slot[T any] struct {
+	lock sync.Mutex
+	v    T
+	set  bool
+}
+
+func (s *slot[T]) save(v T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.set {
+		s.v, s.set = v, true
+	}
+}
+
+// result returns saved value, or ErrNoResult if err is nil but nothing is saved.
+func (s *slot[T]) result(err error) (T, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err == nil && !s.set {
+		err = ErrNoResult
+	}
+	return s.v, err
+}
+
+// DataMiddleware converts a [task.Middleware] to be applied to Data.
+//
+// The middleware is applied once when wrapping, so stateful middleware (like
+// rate limiting or circuit breaker) works as expected. If the middleware runs
+// Data concurrently, first successful value is used.
+//
+// [ErrNoResult] is returned if the middleware succeeds without a successful run of
+// Data, like suppressing the error, skipping it or detaching the context. So
+// middleware like [task.Task.Cached] is not usable.
+func DataMiddleware[T any](mw task.Middleware) func(Data[T]) Data[T] {
+	return func(d Data[T]) Data[T] {
+		key := &slotKey{}
+		t := mw(func(ctx context.Context) error {
+			v, err := d(ctx)
+			if s, ok := ctx.Value(key).(*slot[T]); ok && err == nil {
+				s.save(v)
+			}
+			return err
+		})
+		return func(ctx context.Context) (T, error) {
+			s := &slot[T]{}
+			return s.result(t(context.WithValue(ctx, key, s)))
+		}
+	}
+}
+
+// ActionMiddleware converts a [task.Middleware] to be applied to Action. It
+// returns [ErrDetached] if the middleware detaches the context, or [ErrNoResult] if
+// the middleware succeeds without a successful run of Action.
+func ActionMiddleware[T any](mw task.Middleware) func(Action[T]) Action[T] {
+	return func(a Action[T]) Action[T] {
+		key := &slotKey{}
+		t := mw(func(ctx context.Context) error {
+			s, ok := ctx.Value(key).(*actSlot[T])
+			if !ok {
+				return ErrDetached
+			}
+			err := a(ctx, s.in)
+			if err == nil {
+				s.save(struct{}{})
+			}
+			return err
+		})
+		return func(ctx context.Context, v T) error {
+			s := &actSlot[T]{in: v}
+			_, err := s.result(t(context.WithValue(ctx, key, s)))
+			return err
+		}
+	}
+}
+
+type actSlot[T any] struct {
+	in T
+	slot[struct{}]
+}
+
+type convSlot[I, O any] struct {
+	in I
+	slot[O]
+}
+
+// ConverterMiddleware converts a [task.Middleware] to be applied to Converter,
+// quite like [DataMiddleware]. It returns [ErrDetached] if the middleware detaches
+// the context, or [ErrNoResult] if the middleware succeeds without a successful
+// run of Converter.
+func ConverterMiddleware[I, O any](mw task.Middleware) func(Converter[I, O]) Converter[I, O] {
+	return func(c Converter[I, O]) Converter[I, O] {
+		key := &slotKey{}
+		t := mw(func(ctx context.Context) error {
+			s, ok := ctx.Value(key).(*convSlot[I, O])
+			if !ok {
+				return ErrDetached
+			}
+			v, err := c(ctx, s.in)
+			if err == nil {
+				s.save(v)
+			}
+			return err
+		})
+		return func(ctx context.Context, i I) (O, error) {
+			s := &convSlot[I, O]{in: i}
+			return s.result(t(context.WithValue(ctx, key, s)))
+		}
+	}
+}
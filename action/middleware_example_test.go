@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/raohwork/task"
+)
+
+func ExampleDataMiddleware() {
+	policy := task.Chain(
+		func(t task.Task) task.Task { return t.RetryN(2) },
+		func(t task.Task) task.Task {
+			return t.Post(func(err error) { fmt.Println("attempt:", err) })
+		},
+	)
+
+	n := 0
+	d := DataMiddleware[int](policy)(NoCtxUse(func() (int, error) {
+		n++
+		if n < 2 {
+			return n, errors.New("failed")
+		}
+		return n, nil
+	}))
+	fmt.Println(d.Get(context.Background()))
+
+	save := ActionMiddleware[int](policy)(NoCtxDo(func(v int) error {
+		fmt.Println("save", v)
+		return nil
+	}))
+	fmt.Println(save.Use(d).Run(context.Background()))
+
+	// output: attempt: failed
+	// attempt: <nil>
+	// 2 <nil>
+	// attempt: <nil>
+	// save 3
+	// attempt: <nil>
+	// <nil>
+}
+
+func ExampleErrNoResult() {
+	d := DataMiddleware[int](task.Middleware(task.Task.Cached))(
+		NoCtxUse(func() (int, error) { return 42, nil }),
+	)
+	fmt.Println(d(context.Background()))
+	fmt.Println(d(context.Background()))
+
+	// output: 42 <nil>
+	// 0 middleware returned without a result
+}
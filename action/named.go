@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+
+	"github.com/raohwork/task"
+)
+
+// Named wraps d to run as a span named name. See [task.Task.Named].
+func (d Data[T]) Named(name string) Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		err = task.Task(func(ctx context.Context) (e error) {
+			ret, e = d(ctx)
+			return
+		}).Named(name).Run(ctx)
+		return
+	}
+}
+
+// Named wraps a to run as a span named name. See [task.Task.Named].
+func (a Action[T]) Named(name string) Action[T] {
+	return func(ctx context.Context, v T) error {
+		return a.Apply(v).Named(name).Run(ctx)
+	}
+}
+
+// Named wraps c to run as a span named name. See [task.Task.Named].
+func (c Converter[I, O]) Named(name string) Converter[I, O] {
+	return func(ctx context.Context, i I) (O, error) {
+		return c.By(i).Named(name).Get(ctx)
+	}
+}
@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+
+	"github.com/raohwork/task"
+)
+
+// Recover wraps d to convert a panic into [*task.PanicError].
+func (d Data[T]) Recover() Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		err = task.Task(func(ctx context.Context) (e error) {
+			ret, e = d(ctx)
+			return
+		}).Recover().Run(ctx)
+		return
+	}
+}
+
+// Recover wraps a to convert a panic into [*task.PanicError].
+func (a Action[T]) Recover() Action[T] {
+	return func(ctx context.Context, v T) error {
+		return a.Apply(v).Recover().Run(ctx)
+	}
+}
+
+// Recover wraps c to convert a panic into [*task.PanicError].
+func (c Converter[I, O]) Recover() Converter[I, O] {
+	return func(ctx context.Context, i I) (O, error) {
+		return c.By(i).Recover().Get(ctx)
+	}
+}
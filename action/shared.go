@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"sync"
+
+	"github.com/raohwork/task"
+)
+
+// flight is an in-flight call shared by many callers.
+type flight[T any] struct {
+	done    chan struct{}
+	v       T
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+type flightGroup[T any] struct {
+	lock sync.Mutex
+	m    map[string]*flight[T]
+}
+
+// forget removes the call so further callers start a new one. Caller must hold
+// the lock.
+func (g *flightGroup[T]) forget(key string, c *flight[T]) {
+	if g.m[key] == c {
+		delete(g.m, key)
+	}
+}
+
+func (g *flightGroup[T]) do(ctx context.Context, key string, f Data[T]) (ret T, err error) {
+	g.lock.Lock()
+	if g.m == nil {
+		g.m = map[string]*flight[T]{}
+	}
+	c, ok := g.m[key]
+	if !ok {
+		// the call should not be canceled by first caller, see below
+		runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &flight[T]{done: make(chan struct{}), cancel: cancel}
+		g.m[key] = c
+
+		ch := task.Task(func(ctx context.Context) (err error) {
+			c.v, err = f(ctx)
+			return
+		}).Go(runCtx)
+		go func() {
+			c.err = <-ch
+			g.lock.Lock()
+			g.forget(key, c)
+			g.lock.Unlock()
+			cancel()
+			close(c.done)
+		}()
+	}
+	c.waiters++
+	g.lock.Unlock()
+
+	select {
+	case <-c.done:
+		return c.v, c.err
+	case <-ctx.Done():
+	}
+
+	// cancel the call only if no one is waiting for it
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	c.waiters--
+	if c.waiters == 0 {
+		c.cancel()
+		g.forget(key, c)
+	}
+	return ret, ctx.Err()
+}
+
+// Shared wraps d so concurrent calls are collapsed into one execution, and the
+// result is shared by all callers. Unlike [Data.Cached] and [Data.Saved], result
+// is not kept after the execution.
+//
+// The execution uses the context of first caller, but is not canceled until all
+// callers have canceled their context. Callers return the context error
+// immediately if their context is canceled.
+func (d Data[T]) Shared() Data[T] {
+	g := &flightGroup[T]{}
+	return func(ctx context.Context) (T, error) {
+		return g.do(ctx, "", d)
+	}
+}
+
+// Shared wraps c so concurrent calls with same key are collapsed into one
+// execution, quite like [Data.Shared]. key computes the key from input.
+func (c Converter[I, O]) Shared(key func(I) string) Converter[I, O] {
+	g := &flightGroup[O]{}
+	return func(ctx context.Context, i I) (O, error) {
+		return g.do(ctx, key(i), c.By(i))
+	}
+}
@@ -0,0 +1,126 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package action
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDataShared(t *testing.T) {
+	var cnt atomic.Int32
+	release := make(chan struct{})
+	d := Use(func(ctx context.Context) (int, error) {
+		<-release
+		return int(cnt.Add(1)), ctx.Err()
+	}).Shared()
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = d(context.Background())
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, v := range results {
+		if v != 1 {
+			t.Fatalf("results[%d] = %d, expected 1", i, v)
+		}
+	}
+	if v, _ := d(context.Background()); v != 2 {
+		t.Fatal("result should not be cached, got", v)
+	}
+}
+
+func TestDataSharedCancel(t *testing.T) {
+	release := make(chan struct{})
+	d := Use(func(ctx context.Context) (int, error) {
+		select {
+		case <-release:
+			return 1, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}).Shared()
+
+	leader, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() { _, err := d(leader); errCh <- err }()
+	time.Sleep(10 * time.Millisecond)
+
+	type result struct {
+		v   int
+		err error
+	}
+	follower := make(chan result)
+	go func() { v, err := d(context.Background()); follower <- result{v, err} }()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	if err := <-errCh; err != context.Canceled {
+		t.Fatal("leader should be canceled, got", err)
+	}
+	close(release)
+	if r := <-follower; r.v != 1 || r.err != nil {
+		t.Fatal("follower should get the result, got", r.v, r.err)
+	}
+}
+
+func TestDataSharedAllCanceled(t *testing.T) {
+	canceled := make(chan struct{})
+	d := Use(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(canceled)
+		return 0, ctx.Err()
+	}).Shared()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := d(ctx); err != context.DeadlineExceeded {
+		t.Fatal("unexpected error:", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("execution is not canceled")
+	}
+}
+
+func TestConverterShared(t *testing.T) {
+	var cnt atomic.Int32
+	release := make(chan struct{})
+	c := Get(func(_ context.Context, i int) (string, error) {
+		<-release
+		cnt.Add(1)
+		return strconv.Itoa(i), nil
+	}).Shared(func(i int) string { return strconv.Itoa(i % 2) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c(context.Background(), i%2)
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := cnt.Load(); n != 2 {
+		t.Fatal("expected 2 executions, got", n)
+	}
+}
@@ -0,0 +1,179 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffPolicy computes how long to wait before next retry.
+//
+// n is the count of retries, starting from 1. prev is the delay returned by
+// previous call (0 for first retry), and elapsed is the time passed since first
+// attempt. Returning false stops retrying.
+//
+// A BackoffPolicy should be stateless so it can be shared among goroutines. Use
+// it with [Task.RetryWith].
+type BackoffPolicy func(n int, prev, elapsed time.Duration) (time.Duration, bool)
+
+// ConstantBackoff creates a BackoffPolicy which always waits d.
+func ConstantBackoff(d time.Duration) BackoffPolicy {
+	return func(_ int, _, _ time.Duration) (time.Duration, bool) {
+		return d, true
+	}
+}
+
+// LinearBackoff creates a BackoffPolicy which waits base at first retry, and adds
+// step for each further retry. step can be negative, but the delay never goes
+// below 0.
+func LinearBackoff(base, step time.Duration) BackoffPolicy {
+	return func(n int, _, _ time.Duration) (time.Duration, bool) {
+		d := satAdd(base, satMul(step, float64(n-1)))
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+}
+
+// ExponentialBackoff creates a BackoffPolicy which waits base at first retry, and
+// multiplies the delay by factor for each further retry.
+//
+// factor less than 1 is treated as 2.
+func ExponentialBackoff(base time.Duration, factor float64) BackoffPolicy {
+	if factor < 1 {
+		factor = 2
+	}
+	return func(n int, _, _ time.Duration) (time.Duration, bool) {
+		return satMul(base, math.Pow(factor, float64(n-1))), true
+	}
+}
+
+// DecorrelatedJitter creates a BackoffPolicy described in AWS Architecture Blog
+// "Exponential Backoff And Jitter": the delay is chosen randomly between base and
+// 3 times of previous delay, and never exceeds max.
+func DecorrelatedJitter(base, max time.Duration) BackoffPolicy {
+	return func(_ int, prev, _ time.Duration) (time.Duration, bool) {
+		if prev < base {
+			prev = base
+		}
+		ret := base + randDur(satMul(prev, 3)-base)
+		if ret > max {
+			ret = max
+		}
+		return ret, true
+	}
+}
+
+// FullJitter wraps p to wait a random duration between 0 and the delay computed
+// by p.
+func (p BackoffPolicy) FullJitter() BackoffPolicy {
+	return func(n int, prev, elapsed time.Duration) (time.Duration, bool) {
+		d, ok := p(n, prev, elapsed)
+		if !ok {
+			return 0, false
+		}
+		return randDur(d), true
+	}
+}
+
+// EqualJitter wraps p to wait half of the delay computed by p, plus a random
+// duration between 0 and another half.
+func (p BackoffPolicy) EqualJitter() BackoffPolicy {
+	return func(n int, prev, elapsed time.Duration) (time.Duration, bool) {
+		d, ok := p(n, prev, elapsed)
+		if !ok {
+			return 0, false
+		}
+		half := d / 2
+		return half + randDur(d-half), true
+	}
+}
+
+// Cap wraps p so the delay never exceeds max.
+func (p BackoffPolicy) Cap(max time.Duration) BackoffPolicy {
+	return func(n int, prev, elapsed time.Duration) (time.Duration, bool) {
+		d, ok := p(n, prev, elapsed)
+		if d > max {
+			d = max
+		}
+		return d, ok
+	}
+}
+
+// MaxRetries wraps p to stop retrying after n retries.
+func (p BackoffPolicy) MaxRetries(n int) BackoffPolicy {
+	return func(x int, prev, elapsed time.Duration) (time.Duration, bool) {
+		if x > n {
+			return 0, false
+		}
+		return p(x, prev, elapsed)
+	}
+}
+
+// MaxElapsed wraps p to stop retrying if next attempt will begin after dur passed
+// since first attempt.
+func (p BackoffPolicy) MaxElapsed(dur time.Duration) BackoffPolicy {
+	return func(n int, prev, elapsed time.Duration) (time.Duration, bool) {
+		d, ok := p(n, prev, elapsed)
+		if !ok || satAdd(elapsed, d) > dur {
+			return 0, false
+		}
+		return d, true
+	}
+}
+
+// RetryWith creates a task that repeatedly runs t with same context until it
+// returns nil, waiting between attempts as computed by p.
+//
+// It stops when p returns false or the context is canceled while waiting, and
+// returns last error returned by t.
+func (t Task) RetryWith(p BackoffPolicy) Task {
+	return func(ctx context.Context) (err error) {
+		clock := ClockFrom(ctx)
+		begin := clock.Now()
+		var prev time.Duration
+		for n := 1; ; n++ {
+			err = t.Run(WithAttempt(ctx, n))
+			if err == nil {
+				return
+			}
+
+			d, ok := p(n, prev, clock.Since(begin))
+			if !ok {
+				return
+			}
+			if Sleep(d).Run(ctx) != nil {
+				return
+			}
+			prev = d
+		}
+	}
+}
+
+func randDur(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+func satMul(d time.Duration, f float64) time.Duration {
+	ret := float64(d) * f
+	if ret >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(ret)
+}
+
+func satAdd(a, b time.Duration) time.Duration {
+	if b > 0 && a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
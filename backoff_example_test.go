@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func ExampleTask_RetryWith() {
+	ctx := context.Background()
+	n := 1
+	errTask := func(_ context.Context) error {
+		fmt.Println(n)
+		n++
+		return errors.New("")
+	}
+
+	policy := ExponentialBackoff(10*time.Millisecond, 2).
+		Cap(30 * time.Millisecond).
+		FullJitter().
+		MaxRetries(2)
+	retry := Task(errTask).RetryWith(policy)
+	retry.Run(ctx)
+
+	// output: 1
+	// 2
+	// 3
+}
+
+func ExampleBackoffPolicy() {
+	p := ExponentialBackoff(time.Second, 2).Cap(5 * time.Second)
+	for n := 1; n <= 4; n++ {
+		d, _ := p(n, 0, 0)
+		fmt.Println(d)
+	}
+
+	// output: 1s
+	// 2s
+	// 4s
+	// 5s
+}
@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicy(t *testing.T) {
+	type step struct {
+		n       int
+		elapsed time.Duration
+		expect  time.Duration
+		ok      bool
+	}
+	cases := []struct {
+		name  string
+		p     BackoffPolicy
+		steps []step
+	}{
+		{"constant", ConstantBackoff(time.Second), []step{
+			{1, 0, time.Second, true},
+			{5, 0, time.Second, true},
+		}},
+		{"linear", LinearBackoff(time.Second, 100*time.Millisecond), []step{
+			{1, 0, time.Second, true},
+			{3, 0, 1200 * time.Millisecond, true},
+		}},
+		{"linear negative step", LinearBackoff(time.Second, -100*time.Millisecond), []step{
+			{1, 0, time.Second, true},
+			{2, 0, 900 * time.Millisecond, true},
+			{20, 0, 0, true},
+		}},
+		{"linear overflow", LinearBackoff(time.Second, math.MaxInt64/2), []step{
+			{4, 0, math.MaxInt64, true},
+		}},
+		{"exponential", ExponentialBackoff(time.Second, 2), []step{
+			{1, 0, time.Second, true},
+			{4, 0, 8 * time.Second, true},
+			{100, 0, math.MaxInt64, true},
+		}},
+		{"cap", ExponentialBackoff(time.Second, 2).Cap(5 * time.Second), []step{
+			{2, 0, 2 * time.Second, true},
+			{4, 0, 5 * time.Second, true},
+		}},
+		{"max retries", ConstantBackoff(time.Second).MaxRetries(2), []step{
+			{2, 0, time.Second, true},
+			{3, 0, 0, false},
+		}},
+		{"max elapsed", ConstantBackoff(time.Second).MaxElapsed(10 * time.Second), []step{
+			{1, 9 * time.Second, time.Second, true},
+			{2, 9*time.Second + 1, 0, false},
+			{3, math.MaxInt64, 0, false},
+		}},
+		{"max elapsed negative delay", ConstantBackoff(-time.Second).MaxElapsed(10 * time.Second), []step{
+			{1, 0, -time.Second, true},
+		}},
+	}
+
+	for _, c := range cases {
+		for _, s := range c.steps {
+			d, ok := c.p(s.n, 0, s.elapsed)
+			if d != s.expect || ok != s.ok {
+				t.Errorf("%s #%d: expected (%v, %v), got (%v, %v)", c.name, s.n, s.expect, s.ok, d, ok)
+			}
+		}
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	p := DecorrelatedJitter(time.Second, 10*time.Second)
+	prev := time.Duration(0)
+	for n := 1; n <= 100; n++ {
+		d, ok := p(n, prev, 0)
+		if !ok || d < time.Second || d > 10*time.Second {
+			t.Fatalf("#%d: unexpected delay %v", n, d)
+		}
+		prev = d
+	}
+}
@@ -0,0 +1,268 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// ErrCircuitOpen is returned by wrapped functions when the breaker rejects the
+// call.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the state of a [Breaker].
+type State int
+
+const (
+	// Closed means calls are passed to the dependency.
+	Closed State = iota
+	// Open means calls are rejected with [ErrCircuitOpen].
+	Open
+	// HalfOpen means only limited probe calls are passed to the dependency.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "INVALID"
+	}
+}
+
+// Config configures a [Breaker]. Zero value trips after 5 consecutive failures.
+type Config struct {
+	// Trips the breaker when there are so many consecutive failures. 0 disables
+	// the rule, unless FailureRatio is also 0, which uses 5 instead.
+	ConsecutiveFailures int
+	// Trips the breaker when ratio of failures reaches it, and at least
+	// MinRequests calls have been finished. 0 disables the rule.
+	FailureRatio float64
+	// See FailureRatio.
+	MinRequests int
+	// Counters in closed state are cleared every Interval. 0 means counters are
+	// cleared only when state changes.
+	Interval time.Duration
+	// How long the breaker stays in open state before switching to half-open.
+	// Default to 1 minute.
+	CoolDown time.Duration
+	// Max number of probe calls allowed in half-open state. The breaker is closed
+	// after so many probes succeeded, and is opened again if any of them fail.
+	// Default to 1.
+	HalfOpenProbes int
+	// Decides if an error counts as failure. Default to any error other than
+	// [context.Canceled]. Errors not counted as failure count as success.
+	IsFailure func(error) bool
+	// Called synchronously when state has been changed. It MUST NOT call
+	// methods of the Breaker.
+	OnStateChange func(from, to State)
+	// Clock used to compute Interval and CoolDown. Default to [task.RealClock].
+	Clock task.Clock
+}
+
+// Counts holds statistics of current generation.
+type Counts struct {
+	Requests             int
+	Successes            int
+	Failures             int
+	ConsecutiveSuccesses int
+	ConsecutiveFailures  int
+}
+
+func (c *Counts) success() {
+	c.Successes++
+	c.ConsecutiveSuccesses++
+	c.ConsecutiveFailures = 0
+}
+
+func (c *Counts) failure() {
+	c.Failures++
+	c.ConsecutiveFailures++
+	c.ConsecutiveSuccesses = 0
+}
+
+// Breaker is a thread-safe circuit breaker. Use [Task], [Data] or [Converter] to
+// wrap your functions with it.
+//
+// Zero value is not usable, use [New] to create one.
+type Breaker struct {
+	cfg Config
+
+	lock   sync.Mutex
+	state  State
+	gen    uint64
+	counts Counts
+	expiry time.Time
+}
+
+// New creates a Breaker in closed state.
+func New(cfg Config) *Breaker {
+	if cfg.ConsecutiveFailures <= 0 && cfg.FailureRatio <= 0 {
+		cfg.ConsecutiveFailures = 5
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = time.Minute
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = task.RealClock
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = func(err error) bool {
+			return err != nil && !errors.Is(err, context.Canceled)
+		}
+	}
+
+	ret := &Breaker{cfg: cfg}
+	ret.toGen(cfg.Clock.Now())
+	return ret
+}
+
+// State reports current state.
+func (b *Breaker) State() State {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.current(b.cfg.Clock.Now())
+}
+
+// Counts reports statistics of current generation, which is cleared whenever
+// state changes, or every Config.Interval in closed state.
+func (b *Breaker) Counts() Counts {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.current(b.cfg.Clock.Now())
+	return b.counts
+}
+
+// current updates state according to time. Caller must hold the lock.
+func (b *Breaker) current(now time.Time) State {
+	switch b.state {
+	case Closed:
+		if !b.expiry.IsZero() && !now.Before(b.expiry) {
+			b.toGen(now)
+		}
+	case Open:
+		if !now.Before(b.expiry) {
+			b.setState(HalfOpen, now)
+		}
+	}
+	return b.state
+}
+
+func (b *Breaker) setState(s State, now time.Time) {
+	if b.state == s {
+		return
+	}
+
+	prev := b.state
+	b.state = s
+	b.toGen(now)
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(prev, s)
+	}
+}
+
+func (b *Breaker) toGen(now time.Time) {
+	b.gen++
+	b.counts = Counts{}
+	b.expiry = time.Time{}
+	switch b.state {
+	case Closed:
+		if b.cfg.Interval > 0 {
+			b.expiry = now.Add(b.cfg.Interval)
+		}
+	case Open:
+		b.expiry = now.Add(b.cfg.CoolDown)
+	}
+}
+
+// allow checks if a call can be made, and returns the generation it belongs to.
+func (b *Breaker) allow() (uint64, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.current(b.cfg.Clock.Now()) {
+	case Open:
+		return 0, ErrCircuitOpen
+	case HalfOpen:
+		if b.counts.Requests >= b.cfg.HalfOpenProbes {
+			return 0, ErrCircuitOpen
+		}
+	}
+
+	b.counts.Requests++
+	return b.gen, nil
+}
+
+// done records the result of a call. Results from previous generations are
+// discarded.
+func (b *Breaker) done(gen uint64, err error) {
+	b.record(gen, b.cfg.IsFailure(err))
+}
+
+// finish is deferred by wrapped functions to record the result. A panic is
+// recorded as failure and re-panicked, so the slot taken by allow is released.
+func (b *Breaker) finish(gen uint64, err *error) {
+	if r := recover(); r != nil {
+		b.record(gen, true)
+		panic(r)
+	}
+	b.done(gen, *err)
+}
+
+func (b *Breaker) record(gen uint64, failed bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.cfg.Clock.Now()
+	state := b.current(now)
+	if gen != b.gen {
+		return
+	}
+
+	if !failed {
+		b.counts.success()
+		if state == HalfOpen && b.counts.ConsecutiveSuccesses >= b.cfg.HalfOpenProbes {
+			b.setState(Closed, now)
+		}
+		return
+	}
+
+	b.counts.failure()
+	switch state {
+	case HalfOpen:
+		b.setState(Open, now)
+	case Closed:
+		if b.shouldTrip() {
+			b.setState(Open, now)
+		}
+	}
+}
+
+func (b *Breaker) shouldTrip() bool {
+	c := b.counts
+	if n := b.cfg.ConsecutiveFailures; n > 0 && c.ConsecutiveFailures >= n {
+		return true
+	}
+	if r := b.cfg.FailureRatio; r > 0 {
+		total := c.Successes + c.Failures
+		if total >= b.cfg.MinRequests && float64(c.Failures) >= r*float64(total) {
+			return true
+		}
+	}
+	return false
+}
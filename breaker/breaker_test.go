@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/action"
+	"github.com/raohwork/task/tasktest"
+)
+
+func TestRatio(t *testing.T) {
+	b := New(Config{FailureRatio: 0.5, MinRequests: 4})
+	e := errors.New("fail")
+	results := []error{nil, e, nil, nil, e, e}
+	for idx, err := range results {
+		gen, x := b.allow()
+		if x != nil {
+			t.Fatalf("unexpected rejection at #%d", idx)
+		}
+		b.done(gen, err)
+	}
+	if s := b.State(); s != Open {
+		t.Fatal("expected open, got", s)
+	}
+}
+
+func TestHalfOpenProbes(t *testing.T) {
+	clock := tasktest.NewClock(time.Now())
+	b := New(Config{
+		ConsecutiveFailures: 1,
+		CoolDown:            time.Second,
+		Clock:               clock,
+		HalfOpenProbes:      2,
+	})
+	gen, _ := b.allow()
+	b.done(gen, errors.New("fail"))
+	clock.Advance(time.Second)
+
+	if s := b.State(); s != HalfOpen {
+		t.Fatal("expected half-open, got", s)
+	}
+	g1, err1 := b.allow()
+	g2, err2 := b.allow()
+	if err1 != nil || err2 != nil {
+		t.Fatal("probes should be allowed:", err1, err2)
+	}
+	if _, err := b.allow(); err != ErrCircuitOpen {
+		t.Fatal("too many probes:", err)
+	}
+
+	b.done(g1, nil)
+	if s := b.State(); s != HalfOpen {
+		t.Fatal("expected half-open, got", s)
+	}
+	b.done(g2, nil)
+	if s := b.State(); s != Closed {
+		t.Fatal("expected closed, got", s)
+	}
+}
+
+func TestStaleResult(t *testing.T) {
+	b := New(Config{ConsecutiveFailures: 1, CoolDown: time.Hour})
+	old, _ := b.allow()
+	gen, _ := b.allow()
+	b.done(gen, errors.New("fail"))
+	b.done(old, nil)
+	if s := b.State(); s != Open {
+		t.Fatal("stale result should be ignored, got", s)
+	}
+}
+
+func TestData(t *testing.T) {
+	b := New(Config{ConsecutiveFailures: 1, CoolDown: time.Hour})
+	d := Data(b, action.UseError[int](errors.New("fail")))
+	ctx := context.Background()
+	if _, err := d(ctx); err == nil || err == ErrCircuitOpen {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := d(ctx); err != ErrCircuitOpen {
+		t.Fatal("expected ErrCircuitOpen, got", err)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	clock := tasktest.NewClock(time.Now())
+	b := New(Config{
+		ConsecutiveFailures: 1,
+		CoolDown:            time.Second,
+		Clock:               clock,
+		HalfOpenProbes:      1,
+	})
+	gen, _ := b.allow()
+	b.done(gen, errors.New("fail"))
+	clock.Advance(time.Second)
+
+	probe := Task(b, func(_ context.Context) error { panic("boom") }).Recover()
+	var pe *task.PanicError
+	if err := probe.Run(context.Background()); !errors.As(err, &pe) {
+		t.Fatal("expected panic error, got", err)
+	}
+	if s := b.State(); s != Open {
+		t.Fatal("panic should be recorded as failure, got", s)
+	}
+
+	// slot of the probe is released, so next probe is allowed after cool-down
+	clock.Advance(time.Second)
+	ok := Task(b, func(_ context.Context) error { return nil })
+	if err := ok.Run(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if s := b.State(); s != Closed {
+		t.Fatal("expected closed, got", s)
+	}
+}
@@ -0,0 +1,20 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package breaker provides a circuit breaker to protect failing dependencies.
+//
+// A [Breaker] is shared by any number of wrapped functions. When the dependency
+// behind them keeps failing, the breaker trips to open state and every wrapped
+// function fails fast with [ErrCircuitOpen], instead of hammering the dependency.
+// After a cool-down, a few probe calls are allowed to detect if the dependency is
+// back.
+//
+//	b := breaker.New(breaker.Config{ConsecutiveFailures: 5})
+//	fetch := breaker.Data(b, action.Get(parseResult).From(fetchAPI))
+//	save := breaker.Task(b, action.Do(saveToDB).Use(fetch))
+//
+// It should be applied inside retrying, so each attempt is protected:
+//
+//	breaker.Task(b, myTask).RetryWith(policy)
+package breaker
@@ -0,0 +1,50 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+func Example() {
+	b := New(Config{
+		ConsecutiveFailures: 2,
+		CoolDown:            100 * time.Millisecond,
+		OnStateChange: func(from, to State) {
+			fmt.Printf("%s -> %s\n", from, to)
+		},
+	})
+	fail := true
+	t := Task(b, task.NoCtx(func() error {
+		fmt.Println("executed")
+		if fail {
+			return errors.New("downstream is down")
+		}
+		return nil
+	}))
+	ctx := context.Background()
+
+	t.Run(ctx)                                         // executed
+	t.Run(ctx)                                         // executed, trips the breaker
+	fmt.Println(errors.Is(t.Run(ctx), ErrCircuitOpen)) // fail fast
+
+	time.Sleep(150 * time.Millisecond)
+	fail = false
+	fmt.Println(t.Run(ctx)) // probe call
+
+	// output: executed
+	// executed
+	// closed -> open
+	// true
+	// open -> half-open
+	// executed
+	// half-open -> closed
+	// <nil>
+}
@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package breaker
+
+import (
+	"context"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/action"
+)
+
+// Task creates a [task.Task] protected by b. It fails with [ErrCircuitOpen]
+// without running t if b rejects the call.
+func Task(b *Breaker, t task.Task) task.Task {
+	return func(ctx context.Context) (err error) {
+		gen, err := b.allow()
+		if err != nil {
+			return err
+		}
+		defer b.finish(gen, &err)
+
+		return t.Run(ctx)
+	}
+}
+
+// Data creates an [action.Data] protected by b, quite like [Task].
+func Data[T any](b *Breaker, d action.Data[T]) action.Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		gen, err := b.allow()
+		if err != nil {
+			return
+		}
+		defer b.finish(gen, &err)
+
+		return d(ctx)
+	}
+}
+
+// Converter creates an [action.Converter] protected by b, quite like [Task].
+func Converter[I, O any](b *Breaker, c action.Converter[I, O]) action.Converter[I, O] {
+	return func(ctx context.Context, i I) (ret O, err error) {
+		gen, err := b.allow()
+		if err != nil {
+			return
+		}
+		defer b.finish(gen, &err)
+
+		return c(ctx, i)
+	}
+}
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"time"
+)
+
+// Clock abstracts time-related functions, so time-based tasks like [Sleep],
+// [Task.Timed], [Task.RetryWith] and [Every] can be tested without real waiting.
+//
+// The clock is carried through context, see [WithClock]. Package tasktest provides
+// a manual clock for testing.
+//
+// Deadlines of context (like [Timeout]) are not affected by the clock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a [time.Timer] created by a [Clock].
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// RealClock is the [Clock] uses functions in package time.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type clockKey struct{}
+
+// WithClock creates a context which carries c. Time-based tasks running with it
+// use c instead of real time.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// UseClock creates a CtxMod which applies [WithClock].
+func UseClock(c Clock) CtxMod {
+	return func(ctx context.Context) (context.Context, func()) {
+		return WithClock(ctx, c), func() {}
+	}
+}
+
+// ClockFrom retrieves the [Clock] carried by ctx, or [RealClock] if none.
+func ClockFrom(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return RealClock
+}
@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package cron runs tasks periodically according to cron expressions.
+//
+// # Expression format
+//
+// Both standard 5-field and 6-field (with leading second field) expressions are
+// supported:
+//
+//	Field         Allowed values    Special characters
+//	-----         --------------    ------------------
+//	Second        0-59              * / , -
+//	Minute        0-59              * / , -
+//	Hour          0-23              * / , -
+//	Day of month  1-31              * / , - ?
+//	Month         1-12 or JAN-DEC   * / , -
+//	Day of week   0-7 or SUN-SAT    * / , - ?
+//
+// Both 0 and 7 in day of week field mean Sunday. If both day of month and day of
+// week are restricted (not "*" or "?"), the task runs when either of them matches,
+// as traditional cron does.
+//
+// Following descriptors are also supported:
+//
+//	@yearly (or @annually)  0 0 0 1 1 *
+//	@monthly                0 0 0 1 * *
+//	@weekly                 0 0 0 * * 0
+//	@daily (or @midnight)   0 0 0 * * *
+//	@hourly                 0 0 * * * *
+//	@every <duration>       every duration, see [time.ParseDuration]
+//
+// Time zone can be specified by prefixing "CRON_TZ=" or "TZ=", like
+//
+//	CRON_TZ=Asia/Taipei 30 2 * * 1-5
+//
+// which means "every weekday at 02:30 in Asia/Taipei".
+//
+// # Scheduler
+//
+// [Scheduler] runs registered tasks at scheduled times, and is itself a
+// cancellable [task.Task]:
+//
+//	s := cron.New(time.Local)
+//	s.MustAdd("CRON_TZ=Asia/Taipei 30 2 * * 1-5", backupDB)
+//	s.MustAdd("@every 10s", healthCheck)
+//	err := s.Task().Run(ctx)
+package cron
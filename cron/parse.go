@@ -0,0 +1,326 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes when a task should be run.
+type Schedule interface {
+	// Next returns next activation time after t, or zero time if there's
+	// none.
+	Next(t time.Time) time.Time
+}
+
+// ErrSyntax indicates the cron expression is malformed.
+type ErrSyntax struct {
+	Spec   string
+	Reason string
+}
+
+func (e ErrSyntax) Error() string {
+	return "cron: cannot parse " + strconv.Quote(e.Spec) + ": " + e.Reason
+}
+
+// Every creates a Schedule which activates every d. d less than a second is
+// treated as a second.
+func Every(d time.Duration) Schedule {
+	if d < time.Second {
+		d = time.Second
+	}
+	return every(d)
+}
+
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e)).Truncate(time.Second)
+}
+
+// starBit marks a field as unrestricted ("*" or "?").
+const starBit = 1 << 63
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	secBounds   = bounds{0, 59, nil}
+	minBounds   = bounds{0, 59, nil}
+	hourBounds  = bounds{0, 23, nil}
+	domBounds   = bounds{1, 31, nil}
+	monthBounds = bounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBounds = bounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+type spec struct {
+	sec, min, hour, dom, month, dow uint64
+	loc                             *time.Location
+}
+
+// Parse parses a cron expression. See package document for supported format.
+//
+// Schedules without time zone info compute activation time in the location of
+// the time passed to Next.
+func Parse(expr string) (Schedule, error) {
+	str := strings.TrimSpace(expr)
+	var loc *time.Location
+	if strings.HasPrefix(str, "CRON_TZ=") || strings.HasPrefix(str, "TZ=") {
+		idx := strings.IndexAny(str, " \t")
+		if idx == -1 {
+			return nil, ErrSyntax{expr, "missing expression after time zone"}
+		}
+		name := str[strings.Index(str, "=")+1 : idx]
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, ErrSyntax{expr, "unknown time zone " + name}
+		}
+		loc = l
+		str = strings.TrimSpace(str[idx:])
+	}
+
+	if strings.HasPrefix(str, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(str[len("@every "):]))
+		if err != nil {
+			return nil, ErrSyntax{expr, err.Error()}
+		}
+		if d <= 0 {
+			return nil, ErrSyntax{expr, "duration must be positive"}
+		}
+		return Every(d), nil
+	}
+	if strings.HasPrefix(str, "@") {
+		x, ok := descriptors[strings.ToLower(str)]
+		if !ok {
+			return nil, ErrSyntax{expr, "unknown descriptor " + str}
+		}
+		str = x
+	}
+
+	fields := strings.Fields(str)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, ErrSyntax{expr, "expected 5 or 6 fields, got " + strconv.Itoa(len(fields))}
+	}
+
+	ret := &spec{loc: loc}
+	dst := []*uint64{&ret.sec, &ret.min, &ret.hour, &ret.dom, &ret.month, &ret.dow}
+	all := []bounds{secBounds, minBounds, hourBounds, domBounds, monthBounds, dowBounds}
+	for idx, f := range fields {
+		v, reason := parseField(f, all[idx])
+		if reason != "" {
+			return nil, ErrSyntax{expr, reason}
+		}
+		*dst[idx] = v
+	}
+
+	// 7 is also sunday
+	if ret.dow&(1<<7) > 0 {
+		ret.dow = ret.dow&^(1<<7) | 1
+	}
+	return ret, nil
+}
+
+// MustParse is like Parse, but panics instead of returning error.
+func MustParse(expr string) Schedule {
+	ret, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+func parseField(f string, b bounds) (ret uint64, reason string) {
+	for _, part := range strings.Split(f, ",") {
+		v, r := parseRange(part, b)
+		if r != "" {
+			return 0, r
+		}
+		ret |= v
+	}
+	return
+}
+
+func parseRange(expr string, b bounds) (ret uint64, reason string) {
+	rng, stepStr, hasStep := strings.Cut(expr, "/")
+	var (
+		start, end uint
+		step       uint = 1
+		extra      uint64
+	)
+
+	switch lo, hi, isRange := strings.Cut(rng, "-"); {
+	case rng == "*" || rng == "?":
+		start, end, extra = b.min, b.max, starBit
+	case isRange:
+		if start, reason = parseValue(lo, b); reason != "" {
+			return
+		}
+		if end, reason = parseValue(hi, b); reason != "" {
+			return
+		}
+	default:
+		if start, reason = parseValue(rng, b); reason != "" {
+			return
+		}
+		end = start
+		if hasStep {
+			end = b.max
+		}
+	}
+
+	if hasStep {
+		n, err := strconv.ParseUint(stepStr, 10, 8)
+		if err != nil || n == 0 {
+			return 0, "invalid step " + strconv.Quote(stepStr)
+		}
+		step = uint(n)
+		if step > 1 {
+			extra = 0
+		}
+	}
+
+	if start < b.min || end > b.max {
+		return 0, strconv.Quote(expr) + " is out of range"
+	}
+	if start > end {
+		return 0, strconv.Quote(expr) + " has invalid range"
+	}
+
+	for i := start; i <= end; i += step {
+		ret |= 1 << i
+	}
+	return ret | extra, ""
+}
+
+func parseValue(str string, b bounds) (uint, string) {
+	if v, ok := b.names[strings.ToLower(str)]; ok {
+		return v, ""
+	}
+	v, err := strconv.ParseUint(str, 10, 8)
+	if err != nil {
+		return 0, "invalid value " + strconv.Quote(str)
+	}
+	return uint(v), ""
+}
+
+func has(field uint64, v int) bool { return field&(1<<uint(v)) > 0 }
+
+func (s *spec) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.dom&starBit > 0 || s.dow&starBit > 0 {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next implements [Schedule].
+func (s *spec) Next(t time.Time) time.Time {
+	orig := t.Location()
+	loc := orig
+	if s.loc != nil {
+		loc = s.loc
+		t = t.In(loc)
+	}
+
+	// start from next second
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	limit := t.Year() + 5
+	// truncated tracks if lower units have been reset to their minimum.
+	truncated := false
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+
+	for !has(s.month, int(t.Month())) {
+		if !truncated {
+			truncated = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !truncated {
+			truncated = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+
+	for !has(s.hour, t.Hour()) {
+		if !truncated {
+			truncated = true
+			// truncating by absolute duration, as time.Date picks wrong one if
+			// the wall clock is repeated at the end of daylight saving time
+			t = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		}
+		day := t.Day()
+		// adding absolute duration handles daylight saving time correctly
+		t = t.Add(time.Hour)
+		if t.Day() != day {
+			goto wrap
+		}
+	}
+
+	for !has(s.min, t.Minute()) {
+		if !truncated {
+			truncated = true
+			t = t.Add(-time.Duration(t.Second()) * time.Second)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	for !has(s.sec, t.Second()) {
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto wrap
+		}
+	}
+
+	return t.In(orig)
+}
+
+// count reports number of activations in [from, to].
+func count(s Schedule, from, to time.Time) (ret int) {
+	for t := from; !t.IsZero() && !t.After(to); t = s.Next(t) {
+		ret++
+	}
+	return
+}
@@ -0,0 +1,84 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	for _, name := range []string{"Asia/Taipei", "America/New_York"} {
+		if _, err := time.LoadLocation(name); err != nil {
+			t.Skip("tzdata is not available:", err)
+		}
+	}
+
+	cases := []struct {
+		expr   string
+		from   string
+		expect string
+	}{
+		{"* * * * *", "2024-01-01T00:00:00Z", "2024-01-01T00:01:00Z"},
+		{"* * * * * *", "2024-01-01T00:00:00.5Z", "2024-01-01T00:00:01Z"},
+		{"30 2 * * 1-5", "2024-03-01T03:00:00Z", "2024-03-04T02:30:00Z"},
+		{"*/15 * * * *", "2024-01-01T00:16:00Z", "2024-01-01T00:30:00Z"},
+		{"0 0 1,15 * *", "2024-01-02T00:00:00Z", "2024-01-15T00:00:00Z"},
+		{"0 0 * FEB sun", "2024-01-01T00:00:00Z", "2024-02-04T00:00:00Z"},
+		{"0 0 29 2 *", "2023-03-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"0 0 13 * 5", "2024-01-01T00:00:00Z", "2024-01-05T00:00:00Z"},
+		{"0 0 * * 7", "2024-01-01T00:00:00Z", "2024-01-07T00:00:00Z"},
+		{"@daily", "2024-01-01T12:00:00Z", "2024-01-02T00:00:00Z"},
+		{"@every 90m", "2024-01-01T00:00:00Z", "2024-01-01T01:30:00Z"},
+		{"CRON_TZ=Asia/Taipei 30 2 * * 1-5", "2024-03-01T00:00:00Z", "2024-03-03T18:30:00Z"},
+		{"TZ=America/New_York 30 2 * * *", "2024-03-10T00:00:00-05:00", "2024-03-11T02:30:00-04:00"},
+		{"0 0 30 2 *", "2024-01-01T00:00:00Z", "0001-01-01T00:00:00Z"},
+		// end of daylight saving time, 01:00-02:00 is repeated
+		{"TZ=America/New_York 30 * * * *", "2024-11-03T01:40:00-04:00", "2024-11-03T01:30:00-05:00"},
+		{"TZ=America/New_York 30 * * * *", "2024-11-03T01:40:00-05:00", "2024-11-03T02:30:00-05:00"},
+		{"TZ=America/New_York 0 1 * * *", "2024-11-03T01:00:01-05:00", "2024-11-04T01:00:00-05:00"},
+	}
+
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", c.expr, err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339Nano, c.from)
+		expect, _ := time.Parse(time.RFC3339, c.expect)
+		actual := s.Next(from)
+		if !actual.Equal(expect) {
+			t.Errorf("%s: expected %s, got %s", c.expr, expect, actual)
+		}
+		if !actual.IsZero() && !actual.After(from) {
+			t.Errorf("%s: %s is not after %s", c.expr, actual, from)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	cases := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@often",
+		"@every -1s",
+		"CRON_TZ=Nowhere/Land * * * * *",
+	}
+
+	for _, c := range cases {
+		if _, err := Parse(c); err == nil {
+			t.Errorf("%q: expected error", c)
+		}
+	}
+}
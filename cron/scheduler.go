@@ -0,0 +1,260 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// Overlap defines what to do if a job is activated while previous run is not
+// finished.
+type Overlap int
+
+const (
+	// SkipOverlap skips the activation.
+	SkipOverlap Overlap = iota
+	// QueueOverlap runs the job again right after previous run finished.
+	// Multiple activations are queued.
+	QueueOverlap
+	// AllowOverlap runs the job concurrently.
+	AllowOverlap
+)
+
+// Misfire defines what to do if activations are missed, which happens when the
+// system is suspended or the clock is adjusted.
+type Misfire int
+
+const (
+	// MisfireSkip skips missed activations, and waits for next one.
+	MisfireSkip Misfire = iota
+	// MisfireRunOnce runs the job once no matter how many activations are
+	// missed.
+	MisfireRunOnce
+	// MisfireRunAll runs the job once for each missed activation. Take care of
+	// frequently activated jobs like "@every 1s".
+	MisfireRunAll
+)
+
+// maxSleep limits how long the scheduler sleeps, so changes of wall clock can be
+// detected in time.
+const maxSleep = time.Minute
+
+// Job describes a task to be scheduled.
+type Job struct {
+	Schedule Schedule
+	Task     task.Task
+	Overlap  Overlap
+	Misfire  Misfire
+	// An activation is considered as missed if the scheduler notices it late for
+	// more than Tolerance. Default to 1 second.
+	Tolerance time.Duration
+}
+
+type entry struct {
+	Job
+	next    time.Time
+	running int
+	queued  int
+}
+
+// Scheduler runs jobs at scheduled times. It is safe to add jobs while running.
+//
+// Errors returned by jobs are ignored, use [task.Task.HandleErr] to process them.
+//
+// Zero value is not usable, use [New] to create one.
+type Scheduler struct {
+	loc *time.Location
+
+	lock    sync.Mutex
+	entries []*entry
+	ctx     context.Context
+	wake    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a Scheduler which computes activation time in loc, unless the
+// expression specifies its own time zone. nil loc means [time.Local].
+func New(loc *time.Location) *Scheduler {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Scheduler{
+		loc:  loc,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// now strips monotonic clock reading, so wall clock is used to compare. It uses
+// the [task.Clock] carried by context once running.
+func (s *Scheduler) now() time.Time {
+	clock := task.RealClock
+	if s.ctx != nil {
+		clock = task.ClockFrom(s.ctx)
+	}
+	return clock.Now().Round(0).In(s.loc)
+}
+
+// nextAfter computes next activation after now. A time not after now is never
+// accepted, as it leads to busy looping. In such case, it retries from a later
+// time, and gives up (returns zero time) if it still fails.
+func nextAfter(sch Schedule, now time.Time) time.Time {
+	for _, d := range []time.Duration{0, time.Second, time.Minute, time.Hour} {
+		ret := sch.Next(now.Add(d))
+		if ret.IsZero() || ret.After(now) {
+			return ret
+		}
+	}
+	return time.Time{}
+}
+
+// Add parses expr and adds a job with default policies: [SkipOverlap] and
+// [MisfireSkip].
+func (s *Scheduler) Add(expr string, t task.Task) error {
+	sch, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	s.AddJob(Job{Schedule: sch, Task: t})
+	return nil
+}
+
+// MustAdd is like Add, but panics instead of returning error.
+func (s *Scheduler) MustAdd(expr string, t task.Task) {
+	if err := s.Add(expr, t); err != nil {
+		panic(err)
+	}
+}
+
+// AddJob adds a job to the scheduler.
+func (s *Scheduler) AddJob(j Job) {
+	if j.Tolerance <= 0 {
+		j.Tolerance = time.Second
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	e := &entry{Job: j}
+	s.entries = append(s.entries, e)
+	if s.ctx != nil {
+		e.next = nextAfter(j.Schedule, s.now())
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Task creates a task that runs the scheduler until the context is canceled. It
+// waits running jobs to finish before returning. It can be run only once, further
+// attempt returns [task.ErrOnce].
+//
+// Time is read from the [task.Clock] carried by context.
+func (s *Scheduler) Task() task.Task {
+	return func(ctx context.Context) error {
+		s.lock.Lock()
+		if s.ctx != nil {
+			s.lock.Unlock()
+			return task.ErrOnce
+		}
+		s.ctx = ctx
+		now := s.now()
+		for _, e := range s.entries {
+			e.next = nextAfter(e.Schedule, now)
+		}
+		s.lock.Unlock()
+
+		defer s.wg.Wait()
+		clock := task.ClockFrom(ctx)
+		for {
+			timer := clock.NewTimer(s.fire())
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-s.wake:
+				timer.Stop()
+			case <-timer.C():
+			}
+		}
+	}
+}
+
+// fire triggers jobs due, and computes how long to wait for next activation.
+func (s *Scheduler) fire() (wait time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	wait = maxSleep
+	for _, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+		if !e.next.After(now) {
+			n := 1
+			if now.Sub(e.next) > e.Tolerance {
+				switch e.Misfire {
+				case MisfireSkip:
+					n = 0
+				case MisfireRunAll:
+					n = count(e.Schedule, e.next, now)
+				}
+			}
+			for i := 0; i < n; i++ {
+				s.trigger(e)
+			}
+			e.next = nextAfter(e.Schedule, now)
+			if e.next.IsZero() {
+				continue
+			}
+		}
+
+		if d := e.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	return
+}
+
+// trigger runs the job according to its overlap policy. Caller must hold the
+// lock.
+func (s *Scheduler) trigger(e *entry) {
+	if e.running > 0 {
+		switch e.Overlap {
+		case SkipOverlap:
+			return
+		case QueueOverlap:
+			e.queued++
+			return
+		}
+	}
+	s.start(e)
+}
+
+// start runs the job in separated goroutine. Caller must hold the lock.
+func (s *Scheduler) start(e *entry) {
+	e.running++
+	s.wg.Add(1)
+	task.Task(func(ctx context.Context) error {
+		defer s.finish(e)
+		return e.Task.Run(ctx)
+	}).Go(s.ctx)
+}
+
+func (s *Scheduler) finish(e *entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	defer s.wg.Done()
+
+	e.running--
+	if e.queued > 0 && s.ctx.Err() == nil {
+		e.queued--
+		s.start(e)
+	}
+}
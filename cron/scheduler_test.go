@@ -0,0 +1,143 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/tasktest"
+)
+
+func counter(cnt *atomic.Int32, block chan struct{}) task.Task {
+	return func(ctx context.Context) error {
+		cnt.Add(1)
+		if block != nil {
+			<-block
+		}
+		return nil
+	}
+}
+
+// missed creates a running scheduler (without the loop) with a job missed 10
+// activations.
+func missed(j Job) *Scheduler {
+	s := New(time.UTC)
+	s.ctx = context.Background()
+	s.AddJob(j)
+	s.entries[0].next = s.now().Add(-10 * time.Second)
+	return s
+}
+
+func TestMisfire(t *testing.T) {
+	cases := []struct {
+		policy Misfire
+		expect int32
+	}{
+		{MisfireSkip, 0},
+		{MisfireRunOnce, 1},
+		{MisfireRunAll, 11},
+	}
+
+	for _, c := range cases {
+		var cnt atomic.Int32
+		s := missed(Job{
+			Schedule: Every(time.Second),
+			Task:     counter(&cnt, nil),
+			Overlap:  AllowOverlap,
+			Misfire:  c.policy,
+		})
+		s.fire()
+		s.wg.Wait()
+		if n := cnt.Load(); n != c.expect {
+			t.Errorf("misfire policy %d: expected %d runs, got %d", c.policy, c.expect, n)
+		}
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	cases := []struct {
+		policy Overlap
+		expect int32
+	}{
+		{SkipOverlap, 1},
+		{QueueOverlap, 11},
+		{AllowOverlap, 11},
+	}
+
+	for _, c := range cases {
+		var cnt atomic.Int32
+		block := make(chan struct{})
+		s := missed(Job{
+			Schedule: Every(time.Second),
+			Task:     counter(&cnt, block),
+			Overlap:  c.policy,
+			Misfire:  MisfireRunAll,
+		})
+		s.fire()
+		close(block)
+		s.wg.Wait()
+		if n := cnt.Load(); n != c.expect {
+			t.Errorf("overlap policy %d: expected %d runs, got %d", c.policy, c.expect, n)
+		}
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	clock := tasktest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 5e8, time.UTC))
+	ctx, cancel := context.WithCancel(task.WithClock(context.Background(), clock))
+	ran := make(chan struct{})
+	s := New(time.UTC)
+	s.MustAdd("@every 1s", task.NoErr(func() { ran <- struct{}{} }))
+	done := s.Task().Go(ctx)
+
+	// activations are aligned to second
+	for _, d := range []time.Duration{500 * time.Millisecond, time.Second} {
+		clock.BlockUntil(1)
+		clock.Advance(d)
+		<-ran
+	}
+
+	// not yet
+	clock.BlockUntil(1)
+	clock.Advance(500 * time.Millisecond)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatal("unexpected error:", err)
+	}
+	select {
+	case <-ran:
+		t.Fatal("unexpected run")
+	default:
+	}
+}
+
+func TestFireNeverGoesBack(t *testing.T) {
+	var cnt atomic.Int32
+	s := missed(Job{
+		Schedule: backward{},
+		Task:     counter(&cnt, nil),
+		Misfire:  MisfireRunOnce,
+	})
+	now := s.now()
+	if wait := s.fire(); wait <= 0 {
+		t.Fatal("expected positive wait, got", wait)
+	}
+	s.wg.Wait()
+	if n := cnt.Load(); n != 1 {
+		t.Fatal("expected 1 run, got", n)
+	}
+	if next := s.entries[0].next; !next.IsZero() && !next.After(now) {
+		t.Fatal("expected next activation after now, got", next)
+	}
+}
+
+// backward is a broken schedule always returning a time before t.
+type backward struct{}
+
+func (backward) Next(t time.Time) time.Time { return t.Add(-time.Minute) }
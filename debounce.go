@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/raohwork/task/internal/coalesce"
+)
+
+// ErrDropped is returned to callers of debounced or throttled task if pending
+// execution is dropped by Cancel.
+var ErrDropped = coalesce.ErrDropped
+
+// coalesceClock retrieves the clock from ctx for package coalesce.
+func coalesceClock(ctx context.Context) coalesce.Clock {
+	return coalesce.Wrap[Timer](ClockFrom(ctx))
+}
+
+// Debouncer coalesces bursts of calls into one execution. See [Task.Debounce].
+type Debouncer struct {
+	d *coalesce.Debouncer[struct{}]
+}
+
+// Debounce delays execution of t until no call in wait, or maxWait passed since
+// first delayed call. maxWait less than or equal to 0 means no limit.
+//
+// Coalesced callers wait for the execution, and receive its result. Canceling
+// the context of a caller stops waiting, but does not cancel the execution. The
+// execution uses a context derived from the latest caller by
+// [context.WithoutCancel].
+//
+// The execution runs in separated goroutine unless flushed, so it is wrapped by
+// [Task.Recover] if [SetRecoverByDefault] is enabled. Delays are measured by the
+// [Clock] carried by context of the caller which starts the delay.
+func (t Task) Debounce(wait, maxWait time.Duration) *Debouncer {
+	return &Debouncer{coalesce.NewDebouncer(func(ctx context.Context, _ struct{}) error {
+		return t.recoverIfDefault().Run(ctx)
+	}, wait, maxWait, coalesceClock)}
+}
+
+// Task creates a task which calls the debounced task.
+func (d *Debouncer) Task() Task {
+	return func(ctx context.Context) error { return d.d.Call(ctx, struct{}{}) }
+}
+
+// Flush executes pending execution immediately, and returns its result. It
+// returns nil if nothing is pending.
+func (d *Debouncer) Flush() error { return d.d.Flush() }
+
+// Cancel drops pending execution. Callers waiting for it receive [ErrDropped].
+func (d *Debouncer) Cancel() { d.d.Cancel() }
+
+// Throttler limits a task to run no more than once per interval. See
+// [Task.Throttle].
+type Throttler struct {
+	t *coalesce.Throttler[struct{}]
+}
+
+// Throttle limits t to run no more than once per interval.
+//
+// If leading is true, first call in an interval executes t immediately. If
+// trailing is true, calls in the interval are coalesced into one execution at the
+// end of the interval, which starts a new interval. Otherwise, they share the
+// result of leading execution. If both are false, trailing is used.
+//
+// Like [Task.Debounce], coalesced callers wait for the execution, and receive its
+// result. Panics are recovered if [SetRecoverByDefault] is enabled.
+func (t Task) Throttle(interval time.Duration, leading, trailing bool) *Throttler {
+	return &Throttler{coalesce.NewThrottler(func(ctx context.Context, _ struct{}) error {
+		return t.recoverIfDefault().Run(ctx)
+	}, interval, leading, trailing, coalesceClock)}
+}
+
+// Task creates a task which calls the throttled task.
+func (t *Throttler) Task() Task {
+	return func(ctx context.Context) error { return t.t.Call(ctx, struct{}{}) }
+}
+
+// Flush executes pending trailing execution immediately, and returns its result.
+// It returns nil if nothing is pending.
+func (t *Throttler) Flush() error { return t.t.Flush() }
+
+// Cancel drops pending trailing execution. Callers waiting for it receive
+// [ErrDropped].
+func (t *Throttler) Cancel() { t.t.Cancel() }
@@ -0,0 +1,34 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+func ExampleTask_Debounce() {
+	rebuild := NoErr(func() { fmt.Println("rebuild") }).
+		Debounce(50*time.Millisecond, 0).
+		Task()
+
+	// a burst of file change events
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rebuild.Run(context.Background())
+		}()
+		time.Sleep(10 * time.Millisecond)
+	}
+	wg.Wait()
+	fmt.Println("all callers returned")
+
+	// output: rebuild
+	// all callers returned
+}
@@ -7,4 +7,7 @@
 //
 // [Runner] is designed to handle complex initializing process of large project. All
 // method that executes the tasks follows fail-fast principle.
+//
+// Tasks are run as [task.Task.Named] with their names, so they are traced and
+// labeled in profiles.
 package deptask
@@ -7,6 +7,8 @@ package deptask
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
 
 	"github.com/raohwork/task"
 )
@@ -63,3 +65,25 @@ func ExampleRunner_Skip() {
 	// c
 	// d
 }
+
+func ExampleWithCtxHook() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	ctx := task.WithLogger(context.Background(), logger.With("run", 1))
+
+	r := WithCtxHook(nil, func(ctx context.Context, name string, skipped bool, err error) {
+		task.LoggerFrom(ctx).Info("finished", "node", name, "error", err)
+	})
+	r.MustAdd("a", task.NoErr(func() {}))
+	r.MustAdd("b", task.NoErr(func() {}), "a")
+	r.RunSomeSync(ctx)
+
+	// output: level=INFO msg=finished run=1 node=a error=<nil>
+	// level=INFO msg=finished run=1 node=b error=<nil>
+}
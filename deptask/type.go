@@ -83,7 +83,7 @@ type taskStat struct {
 	err error
 }
 
-func (t *taskStat) Task(name string, pre func(string), post func(string, bool, error)) task.Task {
+func (t *taskStat) Task(name string, pre preHook, post postHook) task.Task {
 	return func(ctx context.Context) error {
 		if t.state == executed {
 			return t.err
@@ -91,14 +91,18 @@ func (t *taskStat) Task(name string, pre func(string), post func(string, bool, e
 
 		if t.state == pending {
 			if pre != nil {
-				pre(name)
+				pre(ctx, name)
 			}
-			t.err = t.task.Run(ctx)
+			run := t.task
+			if task.RecoverByDefault() {
+				run = run.Recover()
+			}
+			t.err = run.Named(name).Run(ctx)
 			t.state = executed
 		}
 
 		if post != nil {
-			post(name, t.state == skipped, t.err)
+			post(ctx, name, t.state == skipped, t.err)
 		}
 		return t.err
 	}
@@ -114,6 +118,27 @@ func New() *Runner { return WithHook(nil, nil) }
 //
 // This was originally designed to show debug log.
 func WithHook(pre func(string), post func(string, bool, error)) *Runner {
+	var (
+		p preHook
+		q postHook
+	)
+	if pre != nil {
+		p = func(_ context.Context, name string) { pre(name) }
+	}
+	if post != nil {
+		q = func(_ context.Context, name string, skipped bool, err error) {
+			post(name, skipped, err)
+		}
+	}
+	return WithCtxHook(p, q)
+}
+
+// WithCtxHook is like WithHook, but hooks receive the context used to run the task,
+// so they can log with the logger carried by it (see [task.LoggerFrom]).
+func WithCtxHook(
+	pre func(ctx context.Context, name string),
+	post func(ctx context.Context, name string, skipped bool, err error),
+) *Runner {
 	return &Runner{
 		deps:  map[string][]string{},
 		tasks: map[string]*taskStat{},
@@ -122,6 +147,11 @@ func WithHook(pre func(string), post func(string, bool, error)) *Runner {
 	}
 }
 
+type (
+	preHook  = func(context.Context, string)
+	postHook = func(context.Context, string, bool, error)
+)
+
 // Runner manages task dependencies and runs the tasks.
 //
 // Runner is not thread-safe, you MUST NOT share same instance among multiple
@@ -135,8 +165,8 @@ func WithHook(pre func(string), post func(string, bool, error)) *Runner {
 type Runner struct {
 	deps    map[string][]string
 	tasks   map[string]*taskStat
-	pre     func(string)
-	post    func(string, bool, error)
+	pre     preHook
+	post    postHook
 	checked bool
 	lastErr error
 	groups  [][]string
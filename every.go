@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"time"
+)
+
+type everyMode int
+
+const (
+	skipMissed everyMode = iota
+	catchUp
+	fixedDelay
+)
+
+type everyConf struct {
+	mode    everyMode
+	initial time.Duration
+	jitter  time.Duration
+	align   bool
+	onErr   func(error)
+}
+
+// EveryOption configures the task created by [Every].
+type EveryOption func(*everyConf)
+
+// SkipMissed runs t at fixed rate. If a run takes too long, missed ticks are
+// skipped. This is the default mode.
+func SkipMissed() EveryOption { return func(c *everyConf) { c.mode = skipMissed } }
+
+// CatchUp runs t at fixed rate. If a run takes too long, missed ticks are run
+// immediately one by one until it catches up.
+func CatchUp() EveryOption { return func(c *everyConf) { c.mode = catchUp } }
+
+// FixedDelay waits the interval after each run finished, instead of running at
+// fixed rate.
+func FixedDelay() EveryOption { return func(c *everyConf) { c.mode = fixedDelay } }
+
+// InitialDelay waits d before first run.
+func InitialDelay(d time.Duration) EveryOption {
+	return func(c *everyConf) { c.initial = d }
+}
+
+// Jitter adds a random duration between 0 and d to every wait. It does not
+// accumulate in fixed rate mode.
+func Jitter(d time.Duration) EveryOption { return func(c *everyConf) { c.jitter = d } }
+
+// AlignToClock aligns ticks to multiples of the interval on wall clock, so
+// Every(10*time.Second, t, AlignToClock()) runs at xx:xx:00, xx:xx:10 and so on.
+// It is ignored in fixed delay mode.
+func AlignToClock() EveryOption { return func(c *everyConf) { c.align = true } }
+
+// OnError reports errors returned by t to f and continues, instead of stopping at
+// first error.
+func OnError(f func(error)) EveryOption { return func(c *everyConf) { c.onErr = f } }
+
+// Every creates a task that runs t periodically until the context is canceled,
+// or t returns an error (see [OnError]). It runs at fixed rate without catching up
+// by default.
+//
+// Unlike [Task.Loop] + [Task.Timed], the time spent by t does not accumulate in
+// fixed rate mode.
+func Every(interval time.Duration, t Task, opts ...EveryOption) Task {
+	var conf everyConf
+	for _, o := range opts {
+		o(&conf)
+	}
+
+	return func(ctx context.Context) error {
+		clock := ClockFrom(ctx)
+		next := clock.Now().Add(conf.initial)
+		if conf.align && conf.mode != fixedDelay && interval > 0 {
+			if x := next.Truncate(interval); x.Before(next) {
+				next = x.Add(interval)
+			}
+		}
+
+		for {
+			if err := Sleep(next.Sub(clock.Now()) + randDur(conf.jitter)).Run(ctx); err != nil {
+				return err
+			}
+
+			if err := t.Run(ctx); err != nil {
+				if conf.onErr == nil {
+					return err
+				}
+				conf.onErr(err)
+			}
+
+			switch conf.mode {
+			case fixedDelay:
+				next = clock.Now().Add(interval)
+			case catchUp:
+				next = next.Add(interval)
+			default:
+				next = next.Add(interval)
+				if now := clock.Now(); interval > 0 && next.Before(now) {
+					next = next.Add((now.Sub(next)/interval + 1) * interval)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func ExampleEvery() {
+	n := 0
+	t := NoCtx(func() error {
+		n++
+		fmt.Println(n)
+		if n%2 == 0 {
+			return errors.New("even")
+		}
+		return nil
+	})
+
+	// run every 100ms, stops at first error
+	err := Every(100*time.Millisecond, t).Run(context.Background())
+	fmt.Println(err)
+
+	// run every 100ms for 350ms, errors are logged
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+	Every(100*time.Millisecond, t, OnError(func(err error) {
+		fmt.Println("error:", err)
+	})).Run(ctx)
+
+	// output: 1
+	// 2
+	// even
+	// 3
+	// 4
+	// error: even
+	// 5
+	// 6
+	// error: even
+}
+
+func ExampleCatchUp() {
+	begin := time.Now()
+	n := 0
+	t := NoCtx(func() error {
+		fmt.Printf("run at +%d00ms\n", time.Since(begin)/(100*time.Millisecond))
+		n++
+		if n == 1 {
+			// a slow run, misses 2 ticks
+			time.Sleep(250 * time.Millisecond)
+		}
+		if n == 4 {
+			return errors.New("stop")
+		}
+		return nil
+	})
+
+	Every(100*time.Millisecond, t, CatchUp()).Run(context.Background())
+
+	// output: run at +000ms
+	// run at +200ms
+	// run at +200ms
+	// run at +300ms
+}
@@ -0,0 +1,83 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLosersRunning indicates some tasks are still running after the grace period
+// of [FirstWith].
+var ErrLosersRunning = errors.New("some tasks are still running after grace period")
+
+// FirstOptions configures [FirstWith].
+type FirstOptions struct {
+	// Grace limits how long to wait for losers after they are canceled. Zero
+	// means waiting until all of them exit.
+	Grace time.Duration
+	// OnLoser, if set, is called with index and returned error of each loser
+	// when it exits, even if it exits after the grace period. It might be called
+	// concurrently with the caller after the grace period.
+	OnLoser func(TaskError)
+}
+
+// FirstWait is like [First], but waits for other tasks to exit after canceling
+// them, so it is safe to release resources used by them once it returns.
+func FirstWait(tasks ...Task) Task {
+	return FirstWith(FirstOptions{}, tasks...)
+}
+
+// FirstWith is like [FirstWait], but the behavior of waiting for losers is
+// configurable by opt.
+//
+// Result of first finished task is returned. If opt.Grace is exceeded, it is
+// joined with [ErrLosersRunning].
+func FirstWith(opt FirstOptions, tasks ...Task) Task {
+	return func(ctx context.Context) (err error) {
+		if len(tasks) == 0 {
+			return
+		}
+		clock := ClockFrom(ctx)
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(ErrOneHasDone)
+
+		ch := make(chan TaskError, len(tasks))
+		for idx, t := range tasks {
+			t.goChild(ctx, "first", idx, ch)
+		}
+
+		err = (<-ch).Err
+		cancel(ErrOneHasDone)
+
+		loser := func(e TaskError) {
+			if opt.OnLoser != nil {
+				opt.OnLoser(e)
+			}
+		}
+		var grace <-chan time.Time
+		if opt.Grace > 0 {
+			timer := clock.NewTimer(opt.Grace)
+			defer timer.Stop()
+			grace = timer.C()
+		}
+
+		for n := 1; n < len(tasks); n++ {
+			select {
+			case e := <-ch:
+				loser(e)
+			case <-grace:
+				go func(n int) {
+					for ; n < len(tasks); n++ {
+						loser(<-ch)
+					}
+				}(n)
+				return errors.Join(err, ErrLosersRunning)
+			}
+		}
+		return
+	}
+}
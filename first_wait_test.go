@@ -0,0 +1,87 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFirstWait(t *testing.T) {
+	var exited atomic.Int32
+	loser := func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		exited.Add(1)
+		return context.Cause(ctx)
+	}
+
+	err := FirstWait(loser, func(_ context.Context) error { return nil }, loser).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	if n := exited.Load(); n != 2 {
+		t.Fatalf("expected losers to exit, got %d", n)
+	}
+}
+
+func TestFirstWithLoser(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		losers = map[int]error{}
+	)
+	opt := FirstOptions{OnLoser: func(e TaskError) {
+		lock.Lock()
+		defer lock.Unlock()
+		losers[e.Index] = e.Err
+	}}
+	errFail := errors.New("fail")
+
+	err := FirstWith(
+		opt,
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return context.Cause(ctx)
+		},
+		func(_ context.Context) error { return errFail },
+	).Run(context.Background())
+	if err != errFail {
+		t.Fatal("unexpected error: ", err)
+	}
+	if len(losers) != 1 || losers[0] != ErrOneHasDone {
+		t.Fatal("unexpected losers: ", losers)
+	}
+}
+
+func TestFirstWithGrace(t *testing.T) {
+	stop := make(chan struct{})
+	reported := make(chan TaskError)
+	opt := FirstOptions{
+		Grace:   10 * time.Millisecond,
+		OnLoser: func(e TaskError) { reported <- e },
+	}
+
+	err := FirstWith(
+		opt,
+		NoErr(func() {}),
+		func(_ context.Context) error {
+			<-stop
+			return nil
+		},
+	).Run(context.Background())
+	if !errors.Is(err, ErrLosersRunning) {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	close(stop)
+	if e := <-reported; e.Index != 1 || e.Err != nil {
+		t.Fatal("unexpected report: ", e)
+	}
+}
@@ -34,13 +34,16 @@ func NoErr(f func()) Task {
 	}
 }
 
-// Sleep is a cancellable [time.Sleep] in task form.
+// Sleep is a cancellable [time.Sleep] in task form. It uses the [Clock] carried by
+// context.
 func Sleep(timeout time.Duration) Task {
 	return func(ctx context.Context) error {
+		timer := ClockFrom(ctx).NewTimer(timeout)
+		defer timer.Stop()
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(timeout):
+		case <-timer.C():
 			return nil
 		}
 	}
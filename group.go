@@ -0,0 +1,156 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrGroupDone indicates the [Group] has finished, no more task can be added.
+var ErrGroupDone = errors.New("the group has finished")
+
+// GroupPolicy defines how a [Group] deals with failed tasks.
+type GroupPolicy int
+
+const (
+	// FailFast cancels other tasks with [ErrOthers] as cancel cause once a task
+	// failed, and returns the error. Tasks not yet started are skipped.
+	FailFast GroupPolicy = iota
+	// CollectAll runs all tasks, and returns all errors as [MultiError]. Index
+	// of [TaskError] is the order tasks were added.
+	CollectAll
+)
+
+type groupTask struct {
+	idx  int
+	task Task
+}
+
+type groupState int
+
+const (
+	groupIdle groupState = iota
+	groupRunning
+	groupDone
+)
+
+// Group is like [Wait] and [Skip], but tasks can be added while others are
+// running.
+//
+// Tasks added before running the group are queued, and are started once the
+// group is started. The group finishes once all added tasks are done, so you
+// have to add at least one task to prevent it from finishing immediately. Tasks
+// can add more tasks to the group.
+//
+// Zero value is a usable Group with FailFast policy and no concurrency limit.
+type Group struct {
+	policy GroupPolicy
+	limit  int
+
+	lock    sync.Mutex
+	state   groupState
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	pending []groupTask
+	added   int
+	running int
+	errs    MultiError
+	done    chan struct{}
+}
+
+// NewGroup creates a Group that runs no more than limit tasks at once. limit less
+// than 1 means no limit.
+func NewGroup(policy GroupPolicy, limit int) *Group {
+	return &Group{policy: policy, limit: limit}
+}
+
+// Go adds t to the group. It returns ErrGroupDone if the group has finished.
+func (g *Group) Go(t Task) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state == groupDone {
+		return ErrGroupDone
+	}
+
+	g.pending = append(g.pending, groupTask{idx: g.added, task: t})
+	g.added++
+	if g.state == groupRunning {
+		g.schedule()
+	}
+	return nil
+}
+
+// schedule starts pending tasks if possible. Caller must hold the lock.
+func (g *Group) schedule() {
+	if g.policy == FailFast && len(g.errs) > 0 {
+		g.pending = nil
+	}
+
+	for len(g.pending) > 0 && (g.limit < 1 || g.running < g.limit) {
+		t := g.pending[0]
+		g.pending[0] = groupTask{}
+		g.pending = g.pending[1:]
+		g.running++
+		go g.run(t)
+	}
+
+	if g.running == 0 && len(g.pending) == 0 {
+		g.state = groupDone
+		close(g.done)
+	}
+}
+
+func (g *Group) run(t groupTask) {
+	ctx, name := withNameSlot(g.ctx)
+	err := t.task.child("group", t.idx).Run(ctx)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.running--
+	if err != nil {
+		g.errs = append(g.errs, TaskError{Index: t.idx, Name: name(), Err: err})
+		if g.policy == FailFast && len(g.errs) == 1 {
+			g.cancel(ErrOthers{err})
+		}
+	}
+	g.schedule()
+}
+
+// Task creates a task that runs the group and waits all tasks done. It can be run
+// only once, further attempt returns ErrOnce.
+//
+// With FailFast policy, first error is returned. With CollectAll policy, a
+// [MultiError] is returned.
+func (g *Group) Task() Task {
+	return func(ctx context.Context) error {
+		g.lock.Lock()
+		if g.state != groupIdle {
+			g.lock.Unlock()
+			return ErrOnce
+		}
+		g.ctx, g.cancel = context.WithCancelCause(ctx)
+		defer g.cancel(nil)
+		g.done = make(chan struct{})
+		g.state = groupRunning
+		g.schedule()
+		g.lock.Unlock()
+
+		<-g.done
+		if len(g.errs) == 0 {
+			return nil
+		}
+		if g.policy == FailFast {
+			return g.errs[0].Err
+		}
+		sort.Slice(g.errs, func(i, j int) bool {
+			return g.errs[i].Index < g.errs[j].Index
+		})
+		return g.errs
+	}
+}
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupDynamic(t *testing.T) {
+	var cnt atomic.Int32
+	g := NewGroup(FailFast, 2)
+	var spawn func(n int) Task
+	spawn = func(n int) Task {
+		return func(_ context.Context) error {
+			cnt.Add(1)
+			if n > 0 {
+				return g.Go(spawn(n - 1))
+			}
+			return nil
+		}
+	}
+	g.Go(spawn(5))
+	g.Go(spawn(5))
+
+	if err := g.Task().Run(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if n := cnt.Load(); n != 12 {
+		t.Fatal("expected 12 runs, got", n)
+	}
+	if err := g.Go(spawn(0)); err != ErrGroupDone {
+		t.Fatal("expected ErrGroupDone, got", err)
+	}
+	if err := g.Task().Run(context.Background()); err != ErrOnce {
+		t.Fatal("expected ErrOnce, got", err)
+	}
+}
+
+func TestGroupFailFast(t *testing.T) {
+	e := errors.New("error")
+	var cause error
+	g := &Group{}
+	g.Go(func(ctx context.Context) error {
+		Sleep(time.Minute).Run(ctx)
+		cause = context.Cause(ctx)
+		return ctx.Err()
+	})
+	g.Go(NoCtx(func() error { return e }))
+
+	if err := g.Task().Run(context.Background()); err != e {
+		t.Fatal("unexpected error:", err)
+	}
+	if !errors.Is(cause, e) {
+		t.Fatal("unexpected cancel cause:", cause)
+	}
+}
+
+func TestGroupCollectAll(t *testing.T) {
+	e1, e2 := errors.New("e1"), errors.New("e2")
+	g := NewGroup(CollectAll, 0)
+	g.Go(NoCtx(func() error { return e1 }))
+	g.Go(NoCtx(func() error { return nil }))
+	g.Go(NoCtx(func() error { return e2 }).Named("e2").RetryN(1))
+
+	err := g.Task().Run(context.Background())
+	if !errors.Is(err, e1) || !errors.Is(err, e2) {
+		t.Fatal("unexpected error:", err)
+	}
+	multi, ok := err.(MultiError)
+	if !ok || len(multi) != 2 || multi[0].Index != 0 || multi[1].Index != 2 {
+		t.Fatalf("unexpected MultiError: %#v", err)
+	}
+	if multi[0].Name != "" || multi[1].Name != "e2" {
+		t.Fatalf("unexpected names: %q, %q", multi[0].Name, multi[1].Name)
+	}
+}
@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"time"
+)
+
+// Hedge creates a task that runs t, and runs a duplicate of t concurrently if no
+// attempt succeeded after delay, up to maxExtra duplicates. First success is
+// returned and other attempts are canceled with ErrOneHasDone as cancel cause.
+//
+// If an attempt fails, a duplicate is started immediately if possible. The error
+// of last attempt is returned if all of them failed.
+//
+// Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
+func (t Task) Hedge(delay time.Duration, maxExtra int) Task {
+	if maxExtra < 0 {
+		maxExtra = 0
+	}
+	return func(ctx context.Context) (err error) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(ErrOneHasDone)
+
+		ch := make(chan TaskError, maxExtra+1)
+		launched, finished := 0, 0
+		launch := func() bool {
+			if launched > maxExtra || ctx.Err() != nil {
+				return false
+			}
+			t.goChild(ctx, "hedge", launched, ch)
+			launched++
+			return true
+		}
+
+		launch()
+		timer := ClockFrom(ctx).NewTimer(delay)
+		defer timer.Stop()
+		for {
+			select {
+			case e := <-ch:
+				finished++
+				if e.Err == nil {
+					return nil
+				}
+				err = e.Err
+				if !launch() && finished == launched {
+					return
+				}
+			case <-timer.C():
+				if launch() {
+					timer.Reset(delay)
+				}
+			}
+		}
+	}
+}
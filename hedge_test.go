@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedge(t *testing.T) {
+	var cnt atomic.Int32
+	cause := make(chan error, 1)
+	slow := Task(func(ctx context.Context) error {
+		if cnt.Add(1) == 1 {
+			// first attempt is slow
+			<-ctx.Done()
+			cause <- context.Cause(ctx)
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	begin := time.Now()
+	if err := slow.Hedge(10*time.Millisecond, 2).Run(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if d := time.Since(begin); d > 50*time.Millisecond {
+		t.Fatal("hedged request is not started in time:", d)
+	}
+	if n := cnt.Load(); n != 2 {
+		t.Fatal("expected 2 attempts, got", n)
+	}
+	if c := <-cause; c != ErrOneHasDone {
+		t.Fatal("unexpected cancel cause:", c)
+	}
+}
+
+func TestHedgeFail(t *testing.T) {
+	var cnt atomic.Int32
+	e := errors.New("error")
+	fail := NoCtx(func() error { cnt.Add(1); return e })
+
+	if err := fail.Hedge(time.Hour, 2).Run(context.Background()); err != e {
+		t.Fatal("unexpected error:", err)
+	}
+	if n := cnt.Load(); n != 3 {
+		t.Fatal("expected 3 attempts, got", n)
+	}
+}
@@ -0,0 +1,302 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package coalesce implements debouncing and throttling shared by package task
+// and action.
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDropped is returned to callers whose pending execution is dropped by Cancel.
+var ErrDropped = errors.New("pending execution has been dropped")
+
+// Clock is the part of task.Clock used by this package. Use [Wrap] to convert a
+// task.Clock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is same as task.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// TimerClock is a clock creates timers of concrete type T, like task.Clock.
+type TimerClock[T Timer] interface {
+	Now() time.Time
+	NewTimer(d time.Duration) T
+}
+
+// Wrap converts c to Clock.
+func Wrap[T Timer](c TimerClock[T]) Clock { return wrapped[T]{c} }
+
+type wrapped[T Timer] struct{ TimerClock[T] }
+
+func (w wrapped[T]) NewTimer(d time.Duration) Timer { return w.TimerClock.NewTimer(d) }
+
+// batch is an execution shared by coalesced callers.
+type batch[T any] struct {
+	v     T
+	ctx   context.Context
+	first time.Time
+	done  chan struct{}
+	err   error
+}
+
+func newBatch[T any](now time.Time) *batch[T] {
+	return &batch[T]{first: now, done: make(chan struct{})}
+}
+
+// set updates the value to execute with. Caller must hold the lock.
+func (b *batch[T]) set(ctx context.Context, v T) {
+	b.ctx, b.v = ctx, v
+}
+
+// exec runs f and releases callers. If f panics, callers receive an error and the
+// panic is propagated.
+func (b *batch[T]) exec(f func(context.Context, T) error) {
+	defer close(b.done)
+	defer func() {
+		if v := recover(); v != nil {
+			b.err = fmt.Errorf("coalesced execution panicked: %v", v)
+			panic(v)
+		}
+	}()
+	b.err = f(context.WithoutCancel(b.ctx), b.v)
+}
+
+func (b *batch[T]) drop() {
+	b.err = ErrDropped
+	close(b.done)
+}
+
+func (b *batch[T]) wait(ctx context.Context) error {
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Debouncer delays execution until no call in wait, or maxWait passed since
+// first delayed call.
+type Debouncer[T any] struct {
+	f             func(context.Context, T) error
+	wait, maxWait time.Duration
+	clockOf       func(context.Context) Clock
+
+	lock    sync.Mutex
+	pending *batch[T]
+	clock   Clock
+	timer   Timer
+}
+
+// NewDebouncer creates a Debouncer. maxWait less than or equal to 0 means no
+// limit.
+//
+// clockOf retrieves the clock from context of caller. Clock of the caller
+// starting a delayed execution is used until it is executed.
+func NewDebouncer[T any](f func(context.Context, T) error, wait, maxWait time.Duration, clockOf func(context.Context) Clock) *Debouncer[T] {
+	return &Debouncer[T]{f: f, wait: wait, maxWait: maxWait, clockOf: clockOf}
+}
+
+// Call schedules an execution with v, and waits for it.
+func (d *Debouncer[T]) Call(ctx context.Context, v T) error {
+	d.lock.Lock()
+	b := d.pending
+	delay := d.wait
+	if b == nil {
+		d.clock = d.clockOf(ctx)
+		b = newBatch[T](d.clock.Now())
+		d.pending = b
+		d.timer = d.clock.NewTimer(delay)
+		go d.fireAfter(b, d.timer)
+	} else {
+		now := d.clock.Now()
+		if d.maxWait > 0 {
+			if remain := b.first.Add(d.maxWait).Sub(now); remain < delay {
+				delay = remain
+			}
+		}
+		d.timer.Reset(delay)
+	}
+	b.set(ctx, v)
+	d.lock.Unlock()
+
+	return b.wait(ctx)
+}
+
+// take removes pending batch if it is b, or any batch if b is nil.
+func (d *Debouncer[T]) take(b *batch[T]) *batch[T] {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.pending == nil || (b != nil && d.pending != b) {
+		return nil
+	}
+	b = d.pending
+	d.pending = nil
+	d.timer.Stop()
+	return b
+}
+
+// fireAfter executes b once timer fires, unless b is flushed or canceled.
+func (d *Debouncer[T]) fireAfter(b *batch[T], timer Timer) {
+	select {
+	case <-timer.C():
+	case <-b.done:
+		return
+	}
+	if b = d.take(b); b != nil {
+		b.exec(d.f)
+	}
+}
+
+// Flush executes pending execution immediately, and returns its result. It
+// returns nil if nothing is pending.
+func (d *Debouncer[T]) Flush() error {
+	b := d.take(nil)
+	if b == nil {
+		return nil
+	}
+	b.exec(d.f)
+	return b.err
+}
+
+// Cancel drops pending execution. Callers waiting for it receive ErrDropped.
+func (d *Debouncer[T]) Cancel() {
+	if b := d.take(nil); b != nil {
+		b.drop()
+	}
+}
+
+// Throttler executes no more than once per interval.
+type Throttler[T any] struct {
+	f                 func(context.Context, T) error
+	interval          time.Duration
+	leading, trailing bool
+	clockOf           func(context.Context) Clock
+
+	lock    sync.Mutex
+	open    bool
+	last    *batch[T]
+	pending *batch[T]
+	clock   Clock
+	timer   Timer
+}
+
+// NewThrottler creates a Throttler. If both leading and trailing are false,
+// trailing is used.
+//
+// clockOf retrieves the clock from context of caller. Clock of the caller
+// starting an interval is used until no more execution is pending.
+func NewThrottler[T any](f func(context.Context, T) error, interval time.Duration, leading, trailing bool, clockOf func(context.Context) Clock) *Throttler[T] {
+	if !leading && !trailing {
+		trailing = true
+	}
+	return &Throttler[T]{f: f, interval: interval, leading: leading, trailing: trailing, clockOf: clockOf}
+}
+
+// openWindow starts a new interval. Caller must hold the lock.
+func (t *Throttler[T]) openWindow() {
+	if t.open {
+		t.timer.Reset(t.interval)
+		return
+	}
+	t.open = true
+	t.timer = t.clock.NewTimer(t.interval)
+	go t.closeWindow(t.timer)
+}
+
+func (t *Throttler[T]) closeWindow(timer Timer) {
+	<-timer.C()
+	t.lock.Lock()
+	t.open = false
+	b := t.pending
+	t.pending = nil
+	if b == nil {
+		t.lock.Unlock()
+		return
+	}
+
+	// trailing execution starts a new interval
+	t.last = b
+	t.openWindow()
+	t.lock.Unlock()
+	b.exec(t.f)
+}
+
+// Call executes f with v, or coalesces it into leading or trailing execution, and
+// waits for it.
+func (t *Throttler[T]) Call(ctx context.Context, v T) error {
+	t.lock.Lock()
+	var b *batch[T]
+	if !t.open {
+		t.clock = t.clockOf(ctx)
+	}
+	switch {
+	case !t.open && t.leading:
+		b = newBatch[T](time.Time{})
+		b.set(ctx, v)
+		t.last = b
+		t.openWindow()
+		t.lock.Unlock()
+		go b.exec(t.f)
+		return b.wait(ctx)
+	case t.trailing:
+		if t.pending == nil {
+			t.pending = newBatch[T](time.Time{})
+		}
+		b = t.pending
+		b.set(ctx, v)
+		if !t.open {
+			t.openWindow()
+		}
+	default:
+		b = t.last
+	}
+	t.lock.Unlock()
+
+	return b.wait(ctx)
+}
+
+// Flush executes pending trailing execution immediately, and returns its result.
+// It returns nil if nothing is pending.
+func (t *Throttler[T]) Flush() error {
+	t.lock.Lock()
+	b := t.pending
+	t.pending = nil
+	if b == nil {
+		t.lock.Unlock()
+		return nil
+	}
+	t.last = b
+	t.openWindow()
+	t.lock.Unlock()
+
+	b.exec(t.f)
+	return b.err
+}
+
+// Cancel drops pending trailing execution. Callers waiting for it receive
+// ErrDropped.
+func (t *Throttler[T]) Cancel() {
+	t.lock.Lock()
+	b := t.pending
+	t.pending = nil
+	t.lock.Unlock()
+
+	if b != nil {
+		b.drop()
+	}
+}
@@ -0,0 +1,196 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package coalesce_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/internal/coalesce"
+	"github.com/raohwork/task/tasktest"
+)
+
+type recorder struct {
+	lock sync.Mutex
+	vals []int
+	ch   chan struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{ch: make(chan struct{}, 100)}
+}
+
+func (r *recorder) f(_ context.Context, v int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.vals = append(r.vals, v)
+	r.ch <- struct{}{}
+	return nil
+}
+
+func (r *recorder) get() []int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]int(nil), r.vals...)
+}
+
+// wait waits until n executions have been recorded.
+func (r *recorder) wait(t *testing.T, n int) {
+	t.Helper()
+	for len(r.get()) < n {
+		select {
+		case <-r.ch:
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d executions, got %v", n, r.get())
+		}
+	}
+}
+
+func clockOf(c *tasktest.Clock) func(context.Context) coalesce.Clock {
+	return func(context.Context) coalesce.Clock { return coalesce.Wrap[task.Timer](c) }
+}
+
+// canceled is passed to calls, so they return once the value is scheduled.
+var canceled = func() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}()
+
+func TestDebounce(t *testing.T) {
+	r := newRecorder()
+	clock := tasktest.NewClock(time.Now())
+	d := coalesce.NewDebouncer(r.f, 20*time.Millisecond, 0, clockOf(clock))
+	for i := 0; i < 5; i++ {
+		d.Call(canceled, i)
+		clock.Advance(5 * time.Millisecond)
+	}
+	if v := r.get(); len(v) != 0 {
+		t.Fatal("unexpected executions before delay:", v)
+	}
+
+	clock.Advance(15 * time.Millisecond)
+	r.wait(t, 1)
+	if v := r.get(); !slices.Equal(v, []int{4}) {
+		t.Fatal("expected one execution with latest value, got", v)
+	}
+}
+
+func TestDebounceMaxWait(t *testing.T) {
+	r := newRecorder()
+	clock := tasktest.NewClock(time.Now())
+	d := coalesce.NewDebouncer(r.f, 20*time.Millisecond, 30*time.Millisecond, clockOf(clock))
+	for i := 0; i < 3; i++ {
+		d.Call(canceled, i)
+		clock.Advance(10 * time.Millisecond)
+	}
+
+	r.wait(t, 1)
+	if v := r.get(); !slices.Equal(v, []int{2}) {
+		t.Fatal("expected maxWait to force execution, got", v)
+	}
+}
+
+func TestDebounceFlushCancel(t *testing.T) {
+	r := newRecorder()
+	clock := tasktest.NewClock(time.Now())
+	d := coalesce.NewDebouncer(r.f, time.Hour, 0, clockOf(clock))
+	ch := make(chan error)
+	go func() { ch <- d.Call(context.Background(), 1) }()
+	clock.BlockUntil(1)
+	if err := d.Flush(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := <-ch; err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	go func() { ch <- d.Call(context.Background(), 2) }()
+	clock.BlockUntil(1)
+	d.Cancel()
+	if err := <-ch; err != coalesce.ErrDropped {
+		t.Fatal("expected ErrDropped, got", err)
+	}
+	if v := r.get(); !slices.Equal(v, []int{1}) {
+		t.Fatal("unexpected executions:", v)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	cases := []struct {
+		leading, trailing bool
+		expect            []int
+	}{
+		{true, true, []int{0, 4}},
+		{true, false, []int{0}},
+		{false, true, []int{4}},
+	}
+
+	for _, c := range cases {
+		r := newRecorder()
+		clock := tasktest.NewClock(time.Now())
+		th := coalesce.NewThrottler(r.f, 30*time.Millisecond, c.leading, c.trailing, clockOf(clock))
+		for i := 0; i < 5; i++ {
+			th.Call(canceled, i)
+			if i == 0 && c.leading {
+				r.wait(t, 1)
+			}
+			clock.Advance(5 * time.Millisecond)
+		}
+		clock.Advance(10 * time.Millisecond)
+		r.wait(t, len(c.expect))
+
+		if v := r.get(); !slices.Equal(v, c.expect) {
+			t.Errorf("leading=%v trailing=%v: expected %v, got %v", c.leading, c.trailing, c.expect, v)
+		}
+	}
+}
+
+func TestThrottleInterval(t *testing.T) {
+	r := newRecorder()
+	clock := tasktest.NewClock(time.Now())
+	th := coalesce.NewThrottler(r.f, 20*time.Millisecond, true, true, clockOf(clock))
+
+	// calls every 5ms, an execution is expected at start of every interval
+	for i := 0; i < 20; i++ {
+		th.Call(canceled, i)
+		if i == 0 {
+			r.wait(t, 1)
+		}
+		clock.Advance(5 * time.Millisecond)
+		if i%4 == 3 {
+			// interval is over, pending call is executed
+			r.wait(t, i/4+2)
+		}
+	}
+
+	if v := r.get(); !slices.Equal(v, []int{0, 3, 7, 11, 15, 19}) {
+		t.Fatal("unexpected executions:", v)
+	}
+}
+
+func TestFlushPanic(t *testing.T) {
+	clock := tasktest.NewClock(time.Now())
+	d := coalesce.NewDebouncer(func(context.Context, int) error { panic("boom") }, time.Hour, 0, clockOf(clock))
+	ch := make(chan error)
+	go func() { ch <- d.Call(context.Background(), 1) }()
+	clock.BlockUntil(1)
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Error("expected panic to be propagated, got", v)
+			}
+		}()
+		d.Flush()
+	}()
+	if err := <-ch; err == nil {
+		t.Fatal("expected error for coalesced caller")
+	}
+}
@@ -0,0 +1,264 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/deptask"
+)
+
+// ErrExited indicates a component started by [App.AddTask] exits before it is
+// stopped.
+var ErrExited = errors.New("component exited unexpectedly")
+
+// ComponentError reports which component failed at which stage.
+type ComponentError struct {
+	Name string
+	// "start", "run" or "stop"
+	Op  string
+	Err error
+}
+
+func (e ComponentError) Error() string {
+	return e.Op + " " + e.Name + ": " + e.Err.Error()
+}
+
+func (e ComponentError) Unwrap() error { return e.Err }
+
+// Component is a part of the application which can be started and stopped.
+type Component struct {
+	// Name of the component, must be unique.
+	Name string
+	// Names of components that must be started before this one, and stopped
+	// after this one.
+	Deps []string
+	// Start starts the component. It should return after the component is
+	// ready. nil means nothing to do.
+	Start func(context.Context) error
+	// Stop stops the component. The context carries its stop deadline. nil
+	// means nothing to do.
+	Stop func(context.Context) error
+	// Deadline of Stop. 0 means limited only by Config.ShutdownTimeout.
+	StopTimeout time.Duration
+}
+
+// Config configures an [App].
+type Config struct {
+	// Total time budget for stopping all components. Default to 30 seconds.
+	ShutdownTimeout time.Duration
+	// Signals to trigger shutdown. Default to SIGINT and SIGTERM.
+	Signals []os.Signal
+	// Do not handle any signal, shutdown is triggered only by context or
+	// component failure.
+	IgnoreSignals bool
+}
+
+// App manages components of an application.
+//
+// Zero value is not usable, use [New] to create one.
+type App struct {
+	cfg   Config
+	comps map[string]Component
+	order []string
+
+	lock   sync.Mutex
+	exited []error
+	notify chan struct{}
+}
+
+// New creates an App.
+func New(cfg Config) *App {
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = 30 * time.Second
+	}
+	if len(cfg.Signals) == 0 {
+		cfg.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return &App{
+		cfg:    cfg,
+		comps:  map[string]Component{},
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// Add adds a component, returns [deptask.ErrDup] if the name has been used.
+func (a *App) Add(c Component) error {
+	if _, ok := a.comps[c.Name]; ok {
+		return deptask.ErrDup
+	}
+	a.comps[c.Name] = c
+	a.order = append(a.order, c.Name)
+	return nil
+}
+
+// MustAdd is like Add, but panics instead of returning error.
+func (a *App) MustAdd(c Component) {
+	if err := a.Add(c); err != nil {
+		panic(err)
+	}
+}
+
+// AddTask adds a long-running task like httptask.Server as a component.
+//
+// Starting the component runs t in separated goroutine, and stopping it cancels
+// the context passed to t and waits it to return. Error returned by t after
+// stopping is ignored. If t returns before stopping, the App shuts down and
+// reports [ErrExited] or the error returned by t.
+func (a *App) AddTask(name string, t task.Task, stopTimeout time.Duration, deps ...string) error {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+	return a.Add(Component{
+		Name:        name,
+		Deps:        deps,
+		StopTimeout: stopTimeout,
+		Start: func(ctx context.Context) error {
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+			done = make(chan struct{})
+			ch := t.Go(runCtx)
+			go func() {
+				defer close(done)
+				err := <-ch
+				if runCtx.Err() != nil {
+					return
+				}
+				if err == nil {
+					err = ErrExited
+				}
+				a.exit(ComponentError{Name: name, Op: "run", Err: err})
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+func (a *App) exit(err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.exited = append(a.exited, err)
+	select {
+	case a.notify <- struct{}{}:
+	default:
+	}
+}
+
+// start starts components in dependency order, returns names of started ones.
+func (a *App) start(ctx context.Context) (started []string, err error) {
+	r := deptask.WithHook(nil, func(name string, skipped bool, err error) {
+		if err == nil && !skipped {
+			started = append(started, name)
+		}
+	})
+	for _, name := range a.order {
+		name := name
+		c := a.comps[name]
+		t := task.Task(func(context.Context) error { return nil })
+		if c.Start != nil {
+			start := c.Start
+			t = func(ctx context.Context) error {
+				if err := start(ctx); err != nil {
+					return ComponentError{Name: name, Op: "start", Err: err}
+				}
+				return nil
+			}
+		}
+		r.MustAdd(name, t, c.Deps...)
+	}
+
+	err = r.RunSomeSync(ctx)
+	return
+}
+
+// stop stops components in reverse order within the budget.
+func (a *App) stop(names []string) (errs []error) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
+	defer cancel()
+
+	for i := len(names) - 1; i >= 0; i-- {
+		c := a.comps[names[i]]
+		if c.Stop == nil {
+			continue
+		}
+
+		if err := a.stopOne(ctx, c); err != nil {
+			errs = append(errs, ComponentError{Name: c.Name, Op: "stop", Err: err})
+		}
+	}
+	return
+}
+
+func (a *App) stopOne(ctx context.Context, c Component) error {
+	if c.StopTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.StopTimeout)
+		defer cancel()
+	}
+
+	// Stop might ignore the context
+	ch := task.Task(c.Stop).Go(ctx)
+	select {
+	case err := <-ch:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Task creates a task that starts all components, waits for shutdown signal and
+// stops them.
+//
+// Shutdown is triggered by receiving signals, canceling the context or a
+// component started by [App.AddTask] exits. Components are stopped with a new
+// context, so canceling the context passed to the task does not cancel the
+// stopping process.
+//
+// It returns all errors occurred when starting, running and stopping components,
+// joined by [errors.Join]. Each error is a [ComponentError], except dependency
+// errors reported by [deptask.Runner.Validate]. Shutdown triggered by signals or
+// context is not an error.
+func (a *App) Task() task.Task {
+	return func(ctx context.Context) error {
+		if !a.cfg.IgnoreSignals {
+			var stop context.CancelFunc
+			ctx, stop = signal.NotifyContext(ctx, a.cfg.Signals...)
+			defer stop()
+		}
+
+		started, err := a.start(ctx)
+		if err != nil {
+			return errors.Join(append([]error{err}, a.stop(started)...)...)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-a.notify:
+		}
+
+		errs := a.stop(started)
+		a.lock.Lock()
+		errs = append(a.exited, errs...)
+		a.lock.Unlock()
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/deptask"
+)
+
+type recorder struct {
+	sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(ev string) {
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *recorder) String() string {
+	r.Lock()
+	defer r.Unlock()
+	return strings.Join(r.events, ",")
+}
+
+func (r *recorder) comp(name string, deps ...string) Component {
+	return Component{
+		Name: name,
+		Deps: deps,
+		Start: func(_ context.Context) error {
+			r.add("start " + name)
+			return nil
+		},
+		Stop: func(_ context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestOrder(t *testing.T) {
+	r := &recorder{}
+	app := New(Config{IgnoreSignals: true})
+	app.MustAdd(r.comp("http", "cache", "db"))
+	app.MustAdd(r.comp("cache", "db"))
+	app.MustAdd(r.comp("db"))
+
+	err := app.Task().With(task.Timeout(10 * time.Millisecond)).Run(context.Background())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := "start db,start cache,start http,stop http,stop cache,stop db"
+	if str := r.String(); str != expect {
+		t.Fatal("unexpected order:", str)
+	}
+}
+
+func TestStartFailure(t *testing.T) {
+	r := &recorder{}
+	e := errors.New("error")
+	app := New(Config{IgnoreSignals: true})
+	app.MustAdd(r.comp("db"))
+	bad := r.comp("cache", "db")
+	bad.Start = func(_ context.Context) error { return e }
+	app.MustAdd(bad)
+	app.MustAdd(r.comp("http", "cache"))
+
+	err := app.Task().Run(context.Background())
+	var ce ComponentError
+	if !errors.As(err, &ce) || ce.Name != "cache" || ce.Op != "start" || !errors.Is(err, e) {
+		t.Fatal("unexpected error:", err)
+	}
+	if str := r.String(); str != "start db,stop db" {
+		t.Fatal("unexpected events:", str)
+	}
+}
+
+func TestTaskExit(t *testing.T) {
+	r := &recorder{}
+	e := errors.New("error")
+	app := New(Config{IgnoreSignals: true})
+	app.MustAdd(r.comp("db"))
+	app.AddTask("worker", task.Sleep(10*time.Millisecond).Then(task.NoCtx(func() error {
+		return e
+	})), 0, "db")
+	app.AddTask("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		r.add("stop server")
+		return ctx.Err()
+	}, 0, "db")
+
+	err := app.Task().Run(context.Background())
+	var ce ComponentError
+	if !errors.As(err, &ce) || ce.Name != "worker" || ce.Op != "run" || !errors.Is(err, e) {
+		t.Fatal("unexpected error:", err)
+	}
+	if str := r.String(); str != "start db,stop server,stop db" {
+		t.Fatal("unexpected events:", str)
+	}
+}
+
+func TestStopTimeout(t *testing.T) {
+	r := &recorder{}
+	app := New(Config{IgnoreSignals: true, ShutdownTimeout: 100 * time.Millisecond})
+	app.MustAdd(r.comp("db"))
+	app.AddTask("stuck", func(_ context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}, 10*time.Millisecond, "db")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	begin := time.Now()
+	err := app.Task().Run(ctx)
+	if time.Since(begin) > 50*time.Millisecond {
+		t.Fatal("stop timeout is not respected")
+	}
+	var ce ComponentError
+	if !errors.As(err, &ce) || ce.Name != "stuck" || ce.Op != "stop" ||
+		!errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("unexpected error:", err)
+	}
+	if str := r.String(); str != "start db,stop db" {
+		t.Fatal("unexpected events:", str)
+	}
+}
+
+func TestDeps(t *testing.T) {
+	r := &recorder{}
+	app := New(Config{IgnoreSignals: true})
+	app.MustAdd(r.comp("http", "db"))
+	if err := app.Task().Run(context.Background()); !errors.Is(err, deptask.ErrMissing("db")) {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := app.Add(r.comp("http")); err != deptask.ErrDup {
+		t.Fatal("expected ErrDup, got", err)
+	}
+}
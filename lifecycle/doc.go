@@ -0,0 +1,18 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package lifecycle manages starting and graceful stopping of components in an
+// application.
+//
+// Components are started in dependency order (see package deptask), and stopped
+// in reverse order when the application receives SIGINT/SIGTERM, the context is
+// canceled, or a component exits unexpectedly. Each component has its own stop
+// deadline, and the whole shutdown process is limited by a total budget.
+//
+//	app := lifecycle.New(lifecycle.Config{ShutdownTimeout: 30 * time.Second})
+//	app.Add(lifecycle.Component{Name: "db", Start: openDB, Stop: closeDB})
+//	app.AddTask("consumer", consumer, 10*time.Second, "db")
+//	app.AddTask("http", httptask.Server(srv), 5*time.Second, "db")
+//	err := app.Task().Run(context.Background())
+package lifecycle
@@ -0,0 +1,118 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"log/slog"
+)
+
+type logKey struct{}
+
+type logConf struct {
+	logger *slog.Logger
+	path   string
+}
+
+// WithLogger creates a context which carries l. Logged tasks running with it log
+// to l by default, so attributes like run ID can be added once:
+//
+//	ctx = task.WithLogger(ctx, logger.With("run", runID))
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	c, _ := ctx.Value(logKey{}).(logConf)
+	c.logger = l
+	return context.WithValue(ctx, logKey{}, c)
+}
+
+// LoggerFrom retrieves the logger carried by ctx, or [slog.Default] if none. If
+// ctx is passed to a logged task, the logger has a "task" attribute, which is the
+// names of nested logged tasks joined by "/".
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	c, _ := ctx.Value(logKey{}).(logConf)
+	ret := c.logger
+	if ret == nil {
+		ret = slog.Default()
+	}
+	if c.path != "" {
+		ret = ret.With(slog.String("task", c.path))
+	}
+	return ret
+}
+
+type logLevels struct {
+	start  slog.Level
+	done   slog.Level
+	failed func(error) slog.Level
+}
+
+// LogOption configures the task created by [Task.Logged].
+type LogOption func(*logLevels)
+
+// LogStart sets level of the message logged before running. Default is
+// [slog.LevelDebug].
+func LogStart(l slog.Level) LogOption { return func(c *logLevels) { c.start = l } }
+
+// LogDone sets level of the message logged after successful run. Default is
+// [slog.LevelInfo].
+func LogDone(l slog.Level) LogOption { return func(c *logLevels) { c.done = l } }
+
+// LogFailed sets the function to compute level of the message logged after
+// failed run. Default is [DefaultFailedLevel].
+func LogFailed(f func(error) slog.Level) LogOption {
+	return func(c *logLevels) { c.failed = f }
+}
+
+// DefaultFailedLevel logs context errors at [slog.LevelDebug], and others at
+// [slog.LevelError].
+func DefaultFailedLevel(err error) slog.Level {
+	if ContextError(err) {
+		return slog.LevelDebug
+	}
+	return slog.LevelError
+}
+
+// Logged creates a task that logs when t begins and finishes, with duration,
+// attempt number (see [Attempt]) and error.
+//
+// If logger is nil, the logger carried by context is used, see [WithLogger].
+// Nested tasks receive a context carrying the logger with name, so they can log
+// with inherited attributes by [LoggerFrom].
+func (t Task) Logged(logger *slog.Logger, name string, opts ...LogOption) Task {
+	lv := logLevels{
+		start:  slog.LevelDebug,
+		done:   slog.LevelInfo,
+		failed: DefaultFailedLevel,
+	}
+	for _, o := range opts {
+		o(&lv)
+	}
+
+	return func(ctx context.Context) error {
+		c, _ := ctx.Value(logKey{}).(logConf)
+		if logger != nil {
+			c.logger = logger
+		}
+		if c.path == "" {
+			c.path = name
+		} else {
+			c.path += "/" + name
+		}
+		ctx = context.WithValue(ctx, logKey{}, c)
+		l := LoggerFrom(ctx)
+		attempt := slog.Int("attempt", Attempt(ctx))
+
+		clock := ClockFrom(ctx)
+		begin := clock.Now()
+		l.LogAttrs(ctx, lv.start, "task started", attempt)
+		err := t.Run(ctx)
+		dur := slog.Duration("duration", clock.Since(begin))
+		if err == nil {
+			l.LogAttrs(ctx, lv.done, "task done", attempt, dur)
+		} else {
+			l.LogAttrs(ctx, lv.failed(err), "task failed", attempt, dur, slog.Any("error", err))
+		}
+		return err
+	}
+}
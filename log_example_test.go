@@ -0,0 +1,52 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+)
+
+func ExampleTask_Logged() {
+	// strips time and duration for testing
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == "duration" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	ctx := WithLogger(context.Background(), logger.With("run", 1))
+
+	n := 0
+	step := NoCtx(func() error {
+		n++
+		if n < 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	report := Task(func(ctx context.Context) error {
+		LoggerFrom(ctx).Info("reporting")
+		return nil
+	})
+
+	Iter(
+		step.Logged(nil, "step").RetryN(1),
+		report,
+	).Logged(nil, "job", LogDone(slog.LevelWarn)).Run(ctx)
+
+	// output: level=DEBUG msg="task started" run=1 task=job attempt=1
+	// level=DEBUG msg="task started" run=1 task=job/step attempt=1
+	// level=ERROR msg="task failed" run=1 task=job/step attempt=1 error=failed
+	// level=DEBUG msg="task started" run=1 task=job/step attempt=2
+	// level=INFO msg="task done" run=1 task=job/step attempt=2
+	// level=INFO msg=reporting run=1 task=job
+	// level=WARN msg="task done" run=1 task=job attempt=1
+}
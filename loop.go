@@ -27,8 +27,8 @@ func (t Task) Loop() Task {
 // Retrying [Micro] task is resource-wasting as it never fail.
 func (t Task) Retry() Task {
 	return func(ctx context.Context) (err error) {
-		for {
-			err = t.Run(ctx)
+		for i := 1; ; i++ {
+			err = t.Run(WithAttempt(ctx, i))
 			if err == nil {
 				return
 			}
@@ -52,7 +52,7 @@ func (t Task) RetryN(n int) Task {
 	n++
 	return func(ctx context.Context) (err error) {
 		for i := 0; i < n; i++ {
-			err = t.Run(ctx)
+			err = t.Run(WithAttempt(ctx, i+1))
 			if err == nil {
 				return
 			}
@@ -71,7 +71,7 @@ func (t Task) RetryNIf(errf func(error) bool, n int) Task {
 	n++
 	return func(ctx context.Context) (err error) {
 		for i := 0; i < n; i++ {
-			err = t.Run(ctx)
+			err = t.Run(WithAttempt(ctx, i+1))
 			if err == nil {
 				return
 			}
@@ -88,8 +88,8 @@ func (t Task) RetryNIf(errf func(error) bool, n int) Task {
 // Error passed to errf can never be nil.
 func (t Task) RetryIf(errf func(error) bool) Task {
 	return func(ctx context.Context) (err error) {
-		for {
-			err = t.Run(ctx)
+		for i := 1; ; i++ {
+			err = t.Run(WithAttempt(ctx, i))
 			if err == nil {
 				return
 			}
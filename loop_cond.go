@@ -0,0 +1,77 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLoopLimit is returned by [Task.LoopUntil] and [Task.LoopWhile] if the loop is
+// stopped by limits in [LoopOptions] before the condition is met.
+var ErrLoopLimit = errors.New("loop limit exceeded")
+
+// LoopOptions controls limits and pacing of [Task.LoopUntil] and [Task.LoopWhile].
+// Zero value means no limit and no pacing.
+type LoopOptions struct {
+	// MaxIterations limits how many times the task runs.
+	MaxIterations int
+	// MaxDuration limits how long to loop. Next iteration is not started if it
+	// would begin after MaxDuration since first iteration began.
+	MaxDuration time.Duration
+	// Interval is how long to wait between end of an iteration and begin of next
+	// one.
+	Interval time.Duration
+}
+
+// LoopUntil creates a task that repeatedly runs t with same context until cond
+// returns true. cond is checked after each run.
+//
+// It stops and returns the error if t returns an error, or [ErrLoopLimit] if it
+// exceeds the limits in opt.
+func (t Task) LoopUntil(cond func() bool, opt LoopOptions) Task {
+	return t.loop(nil, cond, opt)
+}
+
+// LoopWhile creates a task that repeatedly runs t with same context while cond
+// returns true. cond is checked before each run, so t might not be run at all.
+//
+// It stops and returns the error if t returns an error, or [ErrLoopLimit] if it
+// exceeds the limits in opt.
+func (t Task) LoopWhile(cond func() bool, opt LoopOptions) Task {
+	return t.loop(cond, nil, opt)
+}
+
+func (t Task) loop(pre, post func() bool, opt LoopOptions) Task {
+	return func(ctx context.Context) error {
+		clock := ClockFrom(ctx)
+		begin := clock.Now()
+		for n := 1; ; n++ {
+			if pre != nil && !pre() {
+				return nil
+			}
+			if err := t.Run(ctx); err != nil {
+				return err
+			}
+			if post != nil && post() {
+				return nil
+			}
+
+			if (opt.MaxIterations > 0 && n >= opt.MaxIterations) ||
+				(opt.MaxDuration > 0 && clock.Since(begin)+opt.Interval > opt.MaxDuration) {
+				if pre != nil && !pre() {
+					return nil
+				}
+				return ErrLoopLimit
+			}
+			if opt.Interval > 0 {
+				if err := Sleep(opt.Interval).Run(ctx); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -26,3 +26,42 @@ func ExampleTask_RetryN() {
 	// 2
 	// 3
 }
+
+func ExampleTask_LoopUntil() {
+	status := 0
+	poll := NoErr(func() {
+		status++
+		fmt.Println("status:", status)
+	})
+	done := func() bool { return status >= 2 }
+	ctx := context.Background()
+
+	fmt.Println(poll.LoopUntil(done, LoopOptions{MaxIterations: 5}).Run(ctx))
+
+	status = 0
+	fmt.Println(poll.LoopUntil(done, LoopOptions{MaxIterations: 1}).Run(ctx))
+
+	// output: status: 1
+	// status: 2
+	// <nil>
+	// status: 1
+	// loop limit exceeded
+}
+
+func ExampleTask_LoopWhile() {
+	queue := []string{"a", "b"}
+	consume := NoErr(func() {
+		fmt.Println("consume", queue[0])
+		queue = queue[1:]
+	})
+
+	err := consume.LoopWhile(
+		func() bool { return len(queue) > 0 },
+		LoopOptions{},
+	).Run(context.Background())
+	fmt.Println(err)
+
+	// output: consume a
+	// consume b
+	// <nil>
+}
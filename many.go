@@ -38,17 +38,20 @@ var ErrOneHasDone = errors.New("another task has been done")
 // First creates a task that runs tasks concurrently, return first result and cancel
 // others. Other tasks receives ErrOneHasDone as cancel cause.
 //
+// Other tasks might still be running when it returns, use [FirstWait] if you
+// have to wait for them.
+//
 // Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
 func First(tasks ...Task) Task {
 	return func(ctx context.Context) (err error) {
 		ctx, cancel := context.WithCancelCause(ctx)
 
-		ch := make(chan error)
-		for _, t := range tasks {
-			t.GoWithChan(ctx, ch)
+		ch := make(chan TaskError)
+		for idx, t := range tasks {
+			t.goChild(ctx, "first", idx, ch)
 		}
 
-		err = <-ch
+		err = (<-ch).Err
 		cancel(ErrOneHasDone)
 		go func() {
 			for i := 1; i < len(tasks); i++ {
@@ -64,13 +67,13 @@ func First(tasks ...Task) Task {
 // return first non-nil error.
 func Wait(tasks ...Task) Task {
 	return func(ctx context.Context) (err error) {
-		ch := make(chan error)
-		for _, t := range tasks {
-			t.GoWithChan(ctx, ch)
+		ch := make(chan TaskError)
+		for idx, t := range tasks {
+			t.goChild(ctx, "wait", idx, ch)
 		}
 
 		for range tasks {
-			e := <-ch
+			e := (<-ch).Err
 			if err == nil && e != nil {
 				err = e
 			}
@@ -103,13 +106,13 @@ func Skip(tasks ...Task) Task {
 		ctx, cancel := context.WithCancelCause(ctx)
 		defer cancel(nil)
 
-		ch := make(chan error)
-		for _, t := range tasks {
-			t.GoWithChan(ctx, ch)
+		ch := make(chan TaskError)
+		for idx, t := range tasks {
+			t.goChild(ctx, "skip", idx, ch)
 		}
 
 		for range tasks {
-			e := <-ch
+			e := (<-ch).Err
 			if err == nil && e != nil {
 				err = e
 				cancel(ErrOthers{e})
@@ -0,0 +1,136 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// TaskError is an error returned by one of many tasks.
+type TaskError struct {
+	// Position of the task, starting from 0.
+	Index int
+	// Name of the task given by [Task.Named], empty if the task is not named.
+	Name string
+	Err  error
+}
+
+func (e TaskError) Error() string {
+	ret := "task #" + strconv.Itoa(e.Index)
+	if e.Name != "" {
+		ret += " (" + e.Name + ")"
+	}
+	return ret + ": " + e.Err.Error()
+}
+
+func (e TaskError) Unwrap() error { return e.Err }
+
+// MultiError collects errors returned by many tasks, sorted by index.
+//
+// It implements Unwrap() []error, so [errors.Is] and [errors.As] examine every
+// error in it.
+type MultiError []TaskError
+
+func (e MultiError) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(e)))
+	b.WriteString(" tasks failed: ")
+	for idx, err := range e {
+		if idx > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e MultiError) Unwrap() []error {
+	ret := make([]error, len(e))
+	for idx, err := range e {
+		ret[idx] = err
+	}
+	return ret
+}
+
+type nameKey struct{}
+
+// withNameSlot creates a context to receive the name of task running with it, see
+// [Task.Named]. Returned function reports the name, or empty string if unknown.
+func withNameSlot(ctx context.Context) (context.Context, func() string) {
+	p := &atomic.Pointer[string]{}
+	return context.WithValue(ctx, nameKey{}, p), func() string {
+		if s := p.Load(); s != nil {
+			return *s
+		}
+		return ""
+	}
+}
+
+// fillName saves name into the slot carried by ctx if it is empty, and returns a
+// context without the slot, so nested tasks cannot overwrite it.
+func fillName(ctx context.Context, name string) context.Context {
+	p, _ := ctx.Value(nameKey{}).(*atomic.Pointer[string])
+	if p == nil {
+		return ctx
+	}
+	p.CompareAndSwap(nil, &name)
+	return context.WithValue(ctx, nameKey{}, (*atomic.Pointer[string])(nil))
+}
+
+// collectErrs wraps tasks to save their errors, and builds MultiError from them.
+// Panics are recovered inside the wrapper if [SetRecoverByDefault] is enabled, so
+// they are saved too.
+func collectErrs(f func(...Task) Task, tasks []Task) Task {
+	return func(ctx context.Context) error {
+		errs := make([]TaskError, len(tasks))
+		wrapped := make([]Task, len(tasks))
+		for idx, t := range tasks {
+			idx, t := idx, t.recoverIfDefault()
+			wrapped[idx] = func(ctx context.Context) error {
+				ctx, name := withNameSlot(ctx)
+				err := t.Run(ctx)
+				errs[idx] = TaskError{Index: idx, Name: name(), Err: err}
+				return err
+			}
+		}
+
+		err := f(wrapped...).Run(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var ret MultiError
+		for _, e := range errs {
+			if e.Err != nil {
+				ret = append(ret, e)
+			}
+		}
+		if len(ret) == 0 {
+			return err
+		}
+		return ret
+	}
+}
+
+// WaitAll is like [Wait], but returns a [MultiError] containing all non-nil
+// errors.
+func WaitAll(tasks ...Task) Task {
+	return collectErrs(Wait, tasks)
+}
+
+// SkipAll is like [Skip], but returns a [MultiError] containing all non-nil
+// errors, including context errors returned by canceled tasks.
+//
+// Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
+func SkipAll(tasks ...Task) Task {
+	return collectErrs(Skip, tasks)
+}
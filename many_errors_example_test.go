@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+func ExampleWaitAll() {
+	e1 := errors.New("e1")
+	e2 := errors.New("e2")
+	a := NoCtx(func() error { return e1 })
+	b := NoCtx(func() error { return nil })
+	c := NoCtx(func() error { return e2 }).Named("c")
+
+	err := WaitAll(a, b, c).Run(context.Background())
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, e1), errors.Is(err, e2))
+
+	var multi MultiError
+	if errors.As(err, &multi) {
+		for _, e := range multi {
+			fmt.Println(e.Index, e.Name, e.Err)
+		}
+	}
+
+	// output: 2 tasks failed: task #0: e1; task #2 (c): e2
+	// true true
+	// 0  e1
+	// 2 c e2
+}
@@ -0,0 +1,188 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+)
+
+func feed(tasks []Task) <-chan Task {
+	ch := make(chan Task, len(tasks))
+	for _, t := range tasks {
+		ch <- t
+	}
+	close(ch)
+	return ch
+}
+
+// limited runs tasks from channel with no more than limit tasks at once. It stops
+// receiving new tasks once the context is canceled.
+func limited(limit int, tasks <-chan Task, failFast bool) Task {
+	return func(ctx context.Context) (err error) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		kind := "wait"
+		if failFast {
+			kind = "skip"
+		}
+		ch := make(chan TaskError)
+		running := 0
+		collect := func(e error) {
+			running--
+			if err == nil && e != nil {
+				err = e
+				if failFast {
+					cancel(ErrOthers{e})
+				}
+			}
+		}
+
+	loop:
+		for idx := 0; ctx.Err() == nil; {
+			if limit > 0 && running >= limit {
+				collect((<-ch).Err)
+				continue
+			}
+
+			select {
+			case t, ok := <-tasks:
+				if !ok {
+					break loop
+				}
+				running++
+				t.goChild(ctx, kind, idx, ch)
+				idx++
+			case e := <-ch:
+				collect(e.Err)
+			case <-ctx.Done():
+			}
+		}
+
+		for running > 0 {
+			collect((<-ch).Err)
+		}
+		if err == nil {
+			err = ctx.Err()
+		}
+		return
+	}
+}
+
+// WaitN is like [Wait], but runs no more than limit tasks at once. limit less than
+// 1 means no limit.
+//
+// Tasks are started in order. Tasks not yet started are skipped if the context is
+// canceled, and the context error is returned if all started tasks succeeded.
+func WaitN(limit int, tasks ...Task) Task {
+	return func(ctx context.Context) error {
+		return limited(limit, feed(tasks), false).Run(ctx)
+	}
+}
+
+// SkipN is like [Skip], but runs no more than limit tasks at once. limit less than
+// 1 means no limit.
+//
+// Tasks are started in order. Tasks not yet started are skipped if any error
+// occurred or the context is canceled.
+//
+// Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
+func SkipN(limit int, tasks ...Task) Task {
+	return func(ctx context.Context) error {
+		return limited(limit, feed(tasks), true).Run(ctx)
+	}
+}
+
+// WaitStream is like [WaitN], but receives tasks from a channel until it is closed.
+//
+// It stops receiving if the context is canceled, so you might want to stop
+// producing tasks by the same context.
+func WaitStream(limit int, tasks <-chan Task) Task {
+	return limited(limit, tasks, false)
+}
+
+// SkipStream is like [SkipN], but receives tasks from a channel until it is
+// closed.
+//
+// It stops receiving if any error occurred or the context is canceled, so you
+// might want to stop producing tasks by the same context.
+//
+// Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
+func SkipStream(limit int, tasks <-chan Task) Task {
+	return limited(limit, tasks, true)
+}
+
+// firstLimited runs tasks from channel with no more than limit tasks at once,
+// returns first result and cancels others. No more task is started once a result
+// is available or the context is canceled.
+func firstLimited(limit int, tasks <-chan Task) Task {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(ErrOneHasDone)
+
+		ch := make(chan TaskError)
+		running := 0
+		done := func(err error) error {
+			go func(n int) {
+				for ; n > 0; n-- {
+					<-ch
+				}
+			}(running - 1)
+			return err
+		}
+
+		for idx := 0; ctx.Err() == nil; {
+			if limit > 0 && running >= limit {
+				return done((<-ch).Err)
+			}
+
+			select {
+			case t, ok := <-tasks:
+				if !ok {
+					if running == 0 {
+						return nil
+					}
+					return done((<-ch).Err)
+				}
+				running++
+				t.goChild(ctx, "first", idx, ch)
+				idx++
+			case e := <-ch:
+				return done(e.Err)
+			case <-ctx.Done():
+			}
+		}
+
+		if running == 0 {
+			return ctx.Err()
+		}
+		return done((<-ch).Err)
+	}
+}
+
+// FirstN is like [First], but runs no more than limit tasks at once. limit less
+// than 1 means no limit.
+//
+// Tasks are started in order. Once any task is done, running tasks are canceled
+// with ErrOneHasDone as cancel cause, and tasks not yet started are skipped. It
+// returns nil if there's no task.
+//
+// Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
+func FirstN(limit int, tasks ...Task) Task {
+	return func(ctx context.Context) error {
+		return firstLimited(limit, feed(tasks)).Run(ctx)
+	}
+}
+
+// FirstStream is like [FirstN], but receives tasks from a channel until it is
+// closed or any task is done.
+//
+// It stops receiving once it returns, so you might want to stop producing tasks
+// by canceling the context after that.
+//
+// Take care of [NoCtx] and [NoErr] tasks as it cannot be cancelled by context.
+func FirstStream(limit int, tasks <-chan Task) Task {
+	return firstLimited(limit, tasks)
+}
@@ -0,0 +1,164 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type concurrency struct {
+	cur, max atomic.Int32
+	cnt      atomic.Int32
+}
+
+func (c *concurrency) task(err error) Task {
+	return func(ctx context.Context) error {
+		c.cnt.Add(1)
+		n := c.cur.Add(1)
+		defer c.cur.Add(-1)
+		for {
+			m := c.max.Load()
+			if n <= m || c.max.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		if e := Sleep(5 * time.Millisecond).Run(ctx); e != nil {
+			return e
+		}
+		return err
+	}
+}
+
+func TestWaitN(t *testing.T) {
+	c := &concurrency{}
+	e := errors.New("error")
+	tasks := make([]Task, 20)
+	for i := range tasks {
+		tasks[i] = c.task(nil)
+	}
+	tasks[3] = c.task(e)
+
+	if err := WaitN(3, tasks...).Run(context.Background()); err != e {
+		t.Fatal("unexpected error:", err)
+	}
+	if m := c.max.Load(); m != 3 {
+		t.Error("expected max concurrency 3, got", m)
+	}
+	if n := c.cnt.Load(); n != 20 {
+		t.Error("expected 20 tasks executed, got", n)
+	}
+}
+
+func TestSkipN(t *testing.T) {
+	c := &concurrency{}
+	e := errors.New("error")
+	tasks := make([]Task, 20)
+	for i := range tasks {
+		tasks[i] = c.task(nil)
+	}
+	tasks[3] = c.task(e)
+
+	if err := SkipN(3, tasks...).Run(context.Background()); err != e {
+		t.Fatal("unexpected error:", err)
+	}
+	if m := c.max.Load(); m > 3 {
+		t.Error("expected max concurrency 3, got", m)
+	}
+	if n := c.cnt.Load(); n >= 20 {
+		t.Error("expected some tasks skipped, got", n)
+	}
+}
+
+func TestWaitStream(t *testing.T) {
+	c := &concurrency{}
+	ch := make(chan Task)
+	go func() {
+		defer close(ch)
+		for i := 0; i < 10; i++ {
+			ch <- c.task(nil)
+		}
+	}()
+
+	if err := WaitStream(2, ch).Run(context.Background()); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if m := c.max.Load(); m != 2 {
+		t.Error("expected max concurrency 2, got", m)
+	}
+	if n := c.cnt.Load(); n != 10 {
+		t.Error("expected 10 tasks executed, got", n)
+	}
+}
+
+func TestFirstN(t *testing.T) {
+	var cnt atomic.Int32
+	ready := make(chan struct{})
+	start := func() {
+		if cnt.Add(1) == 3 {
+			close(ready)
+		}
+	}
+	e := errors.New("error")
+	causes := make(chan error, 20)
+	tasks := make([]Task, 20)
+	for i := range tasks {
+		tasks[i] = func(ctx context.Context) error {
+			start()
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return ctx.Err()
+		}
+	}
+	// fails after all slots are taken
+	tasks[1] = func(ctx context.Context) error {
+		start()
+		<-ready
+		return e
+	}
+
+	if err := FirstN(3, tasks...).Run(context.Background()); err != e {
+		t.Fatal("unexpected error:", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-causes; err != ErrOneHasDone {
+			t.Error("unexpected cancel cause:", err)
+		}
+	}
+	if n := cnt.Load(); n != 3 {
+		t.Error("expected 3 tasks executed, got", n)
+	}
+}
+
+func TestFirstStream(t *testing.T) {
+	c := &concurrency{}
+	ch := make(chan Task)
+	close(ch)
+	if err := FirstStream(2, ch).Run(context.Background()); err != nil {
+		t.Fatal("unexpected error with no task:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch = make(chan Task)
+	go func() {
+		for i := 0; i < 10; i++ {
+			select {
+			case ch <- c.task(nil):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	if err := FirstStream(2, ch).Run(ctx); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if m := c.max.Load(); m > 2 {
+		t.Error("expected max concurrency 2, got", m)
+	}
+}
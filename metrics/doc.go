@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package metrics instruments tasks and reports measurements to a [Registry].
+//
+// Following metrics are reported, labeled by task name:
+//
+//   - task_runs_total: number of runs.
+//   - task_successes_total: number of successful runs.
+//   - task_failures_total: number of failed runs, also labeled by error class,
+//     see [Classifier].
+//   - task_duration_seconds: histogram of execution time.
+//   - task_in_flight: number of running tasks.
+//   - task_retries_total: number of runs which are retries, see [task.Attempt].
+//   - task_wait_seconds_total: time spent waiting for rate limiter, see
+//     [task.ReportWait].
+//
+// [Memory] is a built-in Registry, which is also an [http.Handler] writing metrics
+// in Prometheus text exposition format:
+//
+//	reg := &metrics.Memory{}
+//	fetch := metrics.Data(reg, "fetch", fetchAPI).RetryN(3)
+//	http.Handle("/metrics", reg)
+//
+// Instrumentation should be applied inside retrying and outside rate limiting, so
+// each attempt is measured and waiting time is reported:
+//
+//	metrics.Task(reg, "job", rated.Task(limiter, job)).RetryN(3)
+package metrics
@@ -0,0 +1,47 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/tasktest"
+)
+
+func Example() {
+	reg := &Memory{Buckets: []float64{1}}
+	clock := tasktest.NewClock(time.Now())
+	ctx := task.WithClock(context.Background(), clock)
+
+	job := Task(reg, "job", tasktest.FailN(2, errors.New("failed"))).RetryN(3)
+	job.Run(ctx)
+	reg.WriteTo(os.Stdout)
+
+	// output: # HELP task_duration_seconds Execution time in seconds.
+	// # TYPE task_duration_seconds histogram
+	// task_duration_seconds_bucket{task="job",le="1"} 3
+	// task_duration_seconds_bucket{task="job",le="+Inf"} 3
+	// task_duration_seconds_sum{task="job"} 0
+	// task_duration_seconds_count{task="job"} 3
+	// # HELP task_failures_total Number of failed runs.
+	// # TYPE task_failures_total counter
+	// task_failures_total{task="job",class="error"} 2
+	// # HELP task_in_flight Number of running tasks.
+	// # TYPE task_in_flight gauge
+	// task_in_flight{task="job"} 0
+	// # HELP task_retries_total Number of runs which are retries.
+	// # TYPE task_retries_total counter
+	// task_retries_total{task="job"} 2
+	// # HELP task_runs_total Number of runs.
+	// # TYPE task_runs_total counter
+	// task_runs_total{task="job"} 3
+	// # HELP task_successes_total Number of successful runs.
+	// # TYPE task_successes_total counter
+	// task_successes_total{task="job"} 1
+}
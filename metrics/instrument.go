@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/action"
+)
+
+// DefaultClassify classifies errors into "canceled", "deadline", "panic" and
+// "error".
+func DefaultClassify(err error) string {
+	var pe *task.PanicError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline"
+	case errors.As(err, &pe):
+		return "panic"
+	default:
+		return "error"
+	}
+}
+
+// classify computes "class" label of err by reg if it implements [Classifier].
+func classify(reg Registry, err error) string {
+	if c, ok := reg.(Classifier); ok {
+		return c.Classify(err)
+	}
+	return DefaultClassify(err)
+}
+
+// Task creates a [task.Task] which reports metrics of t as name to reg.
+//
+// "class" label of failures is computed by reg if it implements [Classifier], or
+// [DefaultClassify] otherwise. A panic of t is reported as a failure with
+// [*task.PanicError], and is propagated.
+func Task(reg Registry, name string, t task.Task) task.Task {
+	lbl := []Label{{Name: "task", Value: name}}
+	return func(ctx context.Context) (err error) {
+		clock := task.ClockFrom(ctx)
+		ctx = task.WithWaitHook(ctx, func(d time.Duration) {
+			reg.Add(Wait, lbl, d.Seconds())
+		})
+
+		reg.Add(Runs, lbl, 1)
+		if task.Attempt(ctx) > 1 {
+			reg.Add(Retries, lbl, 1)
+		}
+		reg.Gauge(InFlight, lbl, 1)
+		begin := clock.Now()
+		defer func() {
+			r := recover()
+			if r != nil {
+				err = &task.PanicError{Value: r, Stack: debug.Stack()}
+			}
+
+			reg.Observe(Duration, lbl, clock.Since(begin).Seconds())
+			reg.Gauge(InFlight, lbl, -1)
+			if err == nil {
+				reg.Add(Successes, lbl, 1)
+			} else {
+				class := classify(reg, err)
+				reg.Add(Failures, []Label{lbl[0], {Name: "class", Value: class}}, 1)
+			}
+
+			if r != nil {
+				panic(r)
+			}
+		}()
+
+		return t.Run(ctx)
+	}
+}
+
+// Middleware creates a [task.Middleware] which applies [Task].
+func Middleware(reg Registry, name string) task.Middleware {
+	return func(t task.Task) task.Task { return Task(reg, name, t) }
+}
+
+// Data creates an [action.Data] which reports metrics of d, quite like [Task].
+func Data[T any](reg Registry, name string, d action.Data[T]) action.Data[T] {
+	return func(ctx context.Context) (ret T, err error) {
+		err = Task(reg, name, func(ctx context.Context) (e error) {
+			ret, e = d(ctx)
+			return
+		}).Run(ctx)
+		return
+	}
+}
+
+// Converter creates an [action.Converter] which reports metrics of c, quite like
+// [Task].
+func Converter[I, O any](reg Registry, name string, c action.Converter[I, O]) action.Converter[I, O] {
+	return func(ctx context.Context, i I) (O, error) {
+		return Data(reg, name, c.By(i)).Get(ctx)
+	}
+}
@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/action"
+	"github.com/raohwork/task/rated"
+	"github.com/raohwork/task/tasktest"
+	"golang.org/x/time/rate"
+)
+
+func TestRatedWait(t *testing.T) {
+	reg := &Memory{}
+	clock := tasktest.NewClock(time.Unix(0, 0))
+	ctx := task.WithClock(context.Background(), clock)
+	l := rate.NewLimiter(rate.Every(time.Second), 1)
+	job := Task(reg, "job", rated.Task(l, task.NoErr(func() {})))
+
+	job.Run(ctx)
+	done := make(chan error)
+	go func() { done <- job.Run(ctx) }()
+	clock.BlockUntil(1)
+	if n := reg.Value(InFlight, Label{"task", "job"}); n != 1 {
+		t.Errorf("expected 1 running task, got %v", n)
+	}
+	clock.Advance(time.Second)
+	<-done
+
+	lbl := Label{"task", "job"}
+	if v := reg.Value(Wait, lbl); v != 1 {
+		t.Errorf("expected 1s waiting, got %v", v)
+	}
+	if v := reg.Value(Duration, lbl); v != 1 {
+		t.Errorf("expected 1s execution time, got %v", v)
+	}
+}
+
+func TestConverter(t *testing.T) {
+	reg := &Memory{}
+	conv := Converter(reg, "parse", action.Converter[string, int](
+		func(ctx context.Context, s string) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+	))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conv(ctx, "1")
+
+	lbl := []Label{{"task", "parse"}, {"class", "canceled"}}
+	if v := reg.Value(Failures, lbl...); v != 1 {
+		t.Errorf("expected 1 canceled failure, got %v", v)
+	}
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), `task_failures_total{task="parse",class="canceled"} 1`) {
+		t.Errorf("unexpected output: %s", body)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type: %s", ct)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	e := errors.New("timeout")
+	reg := &Memory{ClassifyFunc: func(err error) string {
+		if err == e {
+			return "timeout"
+		}
+		return DefaultClassify(err)
+	}}
+	Task(reg, "job", task.NoCtx(func() error { return e })).Run(context.Background())
+
+	lbl := []Label{{"task", "job"}, {"class", "timeout"}}
+	if v := reg.Value(Failures, lbl...); v != 1 {
+		t.Errorf("expected 1 timeout failure, got %v", v)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	reg := &Memory{}
+	boom := task.NoErr(func() { panic("boom") })
+	err := Task(reg, "job", boom).Recover().Run(context.Background())
+	var p *task.PanicError
+	if !errors.As(err, &p) || p.Value != "boom" {
+		t.Fatal("expected panic to be propagated, got", err)
+	}
+
+	lbl := []Label{{"task", "job"}}
+	if v := reg.Value(Successes, lbl...); v != 0 {
+		t.Errorf("expected no success, got %v", v)
+	}
+	if v := reg.Value(InFlight, lbl...); v != 0 {
+		t.Errorf("expected nothing in flight, got %v", v)
+	}
+	lbl = append(lbl, Label{"class", "panic"})
+	if v := reg.Value(Failures, lbl...); v != 1 {
+		t.Errorf("expected 1 panic failure, got %v", v)
+	}
+}
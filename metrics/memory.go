@@ -0,0 +1,246 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets is upper bounds of histogram buckets used by [Memory] if not
+// specified.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type kind int
+
+const (
+	counter kind = iota
+	gauge
+	histogram
+)
+
+func (k kind) String() string {
+	switch k {
+	case counter:
+		return "counter"
+	case gauge:
+		return "gauge"
+	default:
+		return "histogram"
+	}
+}
+
+type series struct {
+	labels []Label
+	value  float64   // counter or gauge, or sum of histogram
+	counts []float64 // cumulated counts of histogram buckets, last one is +Inf
+}
+
+type family struct {
+	kind   kind
+	series map[string]*series
+}
+
+// Memory is a [Registry] which keeps metrics in memory. It implements
+// [http.Handler] to write metrics in Prometheus text exposition format.
+//
+// Zero value is ready to use. It is safe for concurrent use.
+type Memory struct {
+	// Buckets is upper bounds of histogram buckets in ascending order, nil means
+	// DefaultBuckets. It must not be modified once metrics are reported.
+	Buckets []float64
+	// ClassifyFunc computes "class" label of failures, nil means
+	// [DefaultClassify].
+	ClassifyFunc func(error) string
+
+	lock     sync.Mutex
+	families map[string]*family
+}
+
+func (m *Memory) get(name string, k kind, labels []Label) *series {
+	if m.families == nil {
+		m.families = map[string]*family{}
+	}
+	f, ok := m.families[name]
+	if !ok {
+		f = &family{kind: k, series: map[string]*series{}}
+		m.families[name] = f
+	}
+
+	key := formatLabels(labels, "")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: labels}
+		if k == histogram {
+			s.counts = make([]float64, len(m.buckets())+1)
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+func (m *Memory) buckets() []float64 {
+	if m.Buckets == nil {
+		return DefaultBuckets
+	}
+	return m.Buckets
+}
+
+// Classify implements [Classifier].
+func (m *Memory) Classify(err error) string {
+	if m.ClassifyFunc == nil {
+		return DefaultClassify(err)
+	}
+	return m.ClassifyFunc(err)
+}
+
+// Add implements [Registry].
+func (m *Memory) Add(name string, labels []Label, v float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.get(name, counter, labels).value += v
+}
+
+// Gauge implements [Registry].
+func (m *Memory) Gauge(name string, labels []Label, v float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.get(name, gauge, labels).value += v
+}
+
+// Observe implements [Registry].
+func (m *Memory) Observe(name string, labels []Label, v float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	s := m.get(name, histogram, labels)
+	s.value += v
+	for i, b := range m.buckets() {
+		if v <= b {
+			s.counts[i]++
+		}
+	}
+	s.counts[len(s.counts)-1]++
+}
+
+// Value returns current value of a counter or gauge, or sum of a histogram.
+func (m *Memory) Value(name string, labels ...Label) float64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if f, ok := m.families[name]; ok {
+		if s, ok := f.series[formatLabels(labels, "")]; ok {
+			return s.value
+		}
+	}
+	return 0
+}
+
+// WriteTo writes metrics to w in Prometheus text exposition format.
+func (m *Memory) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	buf := bufio.NewWriter(cw)
+
+	m.lock.Lock()
+	names := make([]string, 0, len(m.families))
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := m.families[name]
+		if h, ok := help[name]; ok {
+			buf.WriteString("# HELP " + name + " " + h + "\n")
+		}
+		buf.WriteString("# TYPE " + name + " " + f.kind.String() + "\n")
+
+		keys := make([]string, 0, len(f.series))
+		for k := range f.series {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			m.writeSeries(buf, name, f.kind, f.series[k])
+		}
+	}
+	m.lock.Unlock()
+
+	err := buf.Flush()
+	return cw.n, err
+}
+
+func (m *Memory) writeSeries(w *bufio.Writer, name string, k kind, s *series) {
+	if k != histogram {
+		w.WriteString(name + formatLabels(s.labels, "") + " " + formatFloat(s.value) + "\n")
+		return
+	}
+
+	for i, b := range m.buckets() {
+		w.WriteString(name + "_bucket" + formatLabels(s.labels, formatFloat(b)) +
+			" " + formatFloat(s.counts[i]) + "\n")
+	}
+	cnt := formatFloat(s.counts[len(s.counts)-1])
+	lbl := formatLabels(s.labels, "")
+	w.WriteString(name + "_bucket" + formatLabels(s.labels, "+Inf") + " " + cnt + "\n")
+	w.WriteString(name + "_sum" + lbl + " " + formatFloat(s.value) + "\n")
+	w.WriteString(name + "_count" + lbl + " " + cnt + "\n")
+}
+
+// ServeHTTP implements [http.Handler].
+func (m *Memory) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats labels, with "le" label if le is not empty.
+func formatLabels(labels []Label, le string) string {
+	if len(labels) == 0 && le == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.Name + `="` + escaper.Replace(l.Value) + `"`)
+	}
+	if le != "" {
+		if len(labels) > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(`le="` + le + `"`)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(buf []byte) (int, error) {
+	n, err := w.w.Write(buf)
+	w.n += int64(n)
+	return n, err
+}
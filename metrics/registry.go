@@ -0,0 +1,50 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package metrics
+
+// Label is a name-value pair to identify a series of metric.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Registry receives measurements. Implementations must be safe for concurrent use.
+//
+// Labels passed to the same metric always have the same names in the same order.
+type Registry interface {
+	// Add adds v to a counter.
+	Add(name string, labels []Label, v float64)
+	// Gauge adds v, which can be negative, to a gauge.
+	Gauge(name string, labels []Label, v float64)
+	// Observe records v into a histogram.
+	Observe(name string, labels []Label, v float64)
+}
+
+// Classifier can be implemented by a [Registry] to compute "class" label of
+// failures, see [Task].
+type Classifier interface {
+	Classify(err error) string
+}
+
+// names of metrics
+const (
+	Runs      = "task_runs_total"
+	Successes = "task_successes_total"
+	Failures  = "task_failures_total"
+	Duration  = "task_duration_seconds"
+	InFlight  = "task_in_flight"
+	Retries   = "task_retries_total"
+	Wait      = "task_wait_seconds_total"
+)
+
+var help = map[string]string{
+	Runs:      "Number of runs.",
+	Successes: "Number of successful runs.",
+	Failures:  "Number of failed runs.",
+	Duration:  "Execution time in seconds.",
+	InFlight:  "Number of running tasks.",
+	Retries:   "Number of runs which are retries.",
+	Wait:      "Time spent waiting for rate limiter in seconds.",
+}
@@ -0,0 +1,30 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+// Middleware wraps a task to add some behavior, so a policy can be defined once
+// and applied to many tasks.
+//
+// Wrappers without parameter can be converted to Middleware directly, like
+// Middleware(Task.Recover). Others need a closure:
+//
+//	retry := func(t Task) Task {
+//		return t.RetryWith(ExponentialBackoff(time.Second, 2).MaxRetries(3))
+//	}
+//
+// Use [Chain] to combine them. Package action provides adapters to apply it to
+// Data, Action and Converter.
+type Middleware func(Task) Task
+
+// Chain creates a Middleware by combining mws. First one is the outermost, so
+// Chain(a, b, c)(t) equals to a(b(c(t))).
+func Chain(mws ...Middleware) Middleware {
+	return func(t Task) Task {
+		for i := len(mws) - 1; i >= 0; i-- {
+			t = mws[i](t)
+		}
+		return t
+	}
+}
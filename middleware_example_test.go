@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func ExampleChain() {
+	logErr := func(t Task) Task {
+		return t.Post(func(err error) { fmt.Println("attempt:", err) })
+	}
+	retry := func(t Task) Task {
+		return t.RetryWith(ConstantBackoff(time.Millisecond).MaxRetries(2))
+	}
+	timeout := func(t Task) Task { return t.With(Timeout(time.Second)) }
+	policy := Chain(timeout, retry, logErr)
+
+	n := 0
+	t := policy(NoCtx(func() error {
+		n++
+		if n < 3 {
+			return errors.New("failed")
+		}
+		return nil
+	}))
+	fmt.Println(t.Run(context.Background()))
+
+	// output: attempt: failed
+	// attempt: failed
+	// attempt: <nil>
+	// <nil>
+}
@@ -0,0 +1,50 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"time"
+)
+
+type attemptKey struct{}
+
+// WithAttempt creates a context which marks it is n-th attempt of a retry loop.
+// Retry loops like [Task.RetryN] and [Task.RetryWith] call it before each attempt.
+func WithAttempt(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, n)
+}
+
+// Attempt reports which attempt of innermost retry loop is running, starting from
+// 1. It returns 1 if not running in a retry loop.
+func Attempt(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok {
+		return n
+	}
+	return 1
+}
+
+type waitKey struct{}
+
+// WithWaitHook creates a context which f is called by [ReportWait]. Hooks
+// registered by outer contexts are also called.
+func WithWaitHook(ctx context.Context, f func(time.Duration)) context.Context {
+	if prev, ok := ctx.Value(waitKey{}).(func(time.Duration)); ok {
+		next := f
+		f = func(d time.Duration) {
+			next(d)
+			prev(d)
+		}
+	}
+	return context.WithValue(ctx, waitKey{}, f)
+}
+
+// ReportWait reports that d is spent waiting for a limiter like package rated
+// before running the task, to hooks registered by [WithWaitHook].
+func ReportWait(ctx context.Context, d time.Duration) {
+	if f, ok := ctx.Value(waitKey{}).(func(time.Duration)); ok {
+		f(d)
+	}
+}
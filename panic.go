@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is returned by a recovered task if it panics.
+type PanicError struct {
+	// Value passed to panic().
+	Value any
+	// Stack trace of the goroutine when recovered, see [debug.Stack].
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered from panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic() if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Recover wraps t to convert a panic into [*PanicError].
+func (t Task) Recover() Task {
+	return func(ctx context.Context) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = &PanicError{Value: v, Stack: debug.Stack()}
+			}
+		}()
+		return t.Run(ctx)
+	}
+}
+
+var recoverByDefault atomic.Bool
+
+// SetRecoverByDefault controls whether tasks run in separated goroutine by this
+// package ([Task.Go], [First], [Wait], [Skip], [Group] and so on) and tasks run by
+// deptask.Runner are wrapped by [Task.Recover] automatically.
+//
+// It is disabled by default, and should be set before running any task.
+func SetRecoverByDefault(enabled bool) { recoverByDefault.Store(enabled) }
+
+// RecoverByDefault reports the value set by [SetRecoverByDefault].
+func RecoverByDefault() bool { return recoverByDefault.Load() }
+
+// recoverIfDefault wraps t with Recover if SetRecoverByDefault(true) is called.
+func (t Task) recoverIfDefault() Task {
+	if RecoverByDefault() {
+		return t.Recover()
+	}
+	return t
+}
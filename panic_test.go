@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecover(t *testing.T) {
+	e := errors.New("error")
+	err := NoErr(func() { panic(e) }).Recover().Run(context.Background())
+
+	var p *PanicError
+	if !errors.As(err, &p) {
+		t.Fatal("expected PanicError, got", err)
+	}
+	if p.Value != e || !errors.Is(err, e) {
+		t.Fatal("unexpected panic value:", p.Value)
+	}
+	if !bytes.Contains(p.Stack, []byte("TestRecover")) {
+		t.Fatal("stack does not contain panic site:", string(p.Stack))
+	}
+}
+
+func TestRecoverByDefault(t *testing.T) {
+	SetRecoverByDefault(true)
+	defer SetRecoverByDefault(false)
+
+	boom := NoErr(func() { panic("boom") })
+	ok := NoErr(func() {})
+	var p *PanicError
+	if err := Wait(ok, boom).Run(context.Background()); !errors.As(err, &p) {
+		t.Fatal("expected PanicError from Wait, got", err)
+	}
+	if err := <-boom.Go(context.Background()); !errors.As(err, &p) {
+		t.Fatal("expected PanicError from Go, got", err)
+	}
+
+	for name, f := range map[string]func(...Task) Task{"WaitAll": WaitAll, "SkipAll": SkipAll} {
+		err := f(ok, boom).Run(context.Background())
+		multi, _ := err.(MultiError)
+		if len(multi) == 0 || multi[len(multi)-1].Index != 1 || !errors.As(multi[len(multi)-1], &p) {
+			t.Fatalf("expected PanicError of task #1 from %s, got %v", name, err)
+		}
+	}
+
+	g := &Group{}
+	g.Go(boom)
+	if err := g.Task().Run(context.Background()); !errors.As(err, &p) {
+		t.Fatal("expected PanicError from Group, got", err)
+	}
+
+	d := boom.Debounce(time.Millisecond, 0)
+	if err := d.Task().Run(context.Background()); !errors.As(err, &p) {
+		t.Fatal("expected PanicError from Debouncer, got", err)
+	}
+}
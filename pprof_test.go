@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNamedLabels(t *testing.T) {
+	var labels []string
+	record := func(ctx context.Context) error {
+		v, _ := pprof.Label(ctx, "task")
+		labels = append(labels, v)
+		return nil
+	}
+
+	err := Iter(
+		record,
+		Task(record).Named("inner"),
+		Wait(record),
+	).Named("outer").Run(context.Background())
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	expect := []string{"outer", "inner", "outer"}
+	if !slices.Equal(labels, expect) {
+		t.Fatalf("expected %q, got %q", expect, labels)
+	}
+}
+
+func TestNamedGoroutineLabels(t *testing.T) {
+	started, stop := make(chan struct{}), make(chan struct{})
+	block := func(_ context.Context) error {
+		close(started)
+		<-stop
+		return nil
+	}
+	done := Skip(block).Named("labeled").Go(context.Background())
+
+	<-started
+	buf := &bytes.Buffer{}
+	pprof.Lookup("goroutine").WriteTo(buf, 1)
+	close(stop)
+	<-done
+
+	// both the goroutine running Skip and the child spawned by it
+	if n := strings.Count(buf.String(), `"task":"labeled"`); n < 2 {
+		t.Fatalf("expected 2 labeled goroutines, got %d:\n%s", n, buf)
+	}
+}
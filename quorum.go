@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrQuorumReached is the cancel cause of tasks canceled by [Quorum] as
+	// enough tasks have succeeded.
+	ErrQuorumReached = errors.New("quorum has been reached")
+	// ErrNoQuorum is the cancel cause of tasks canceled by [Quorum] as too many
+	// tasks have failed. It is also returned if k is greater than number of
+	// tasks.
+	ErrNoQuorum = errors.New("quorum cannot be reached")
+)
+
+// Quorum creates a task that runs tasks concurrently, and succeeds once k of them
+// succeeded. Others are canceled with [ErrQuorumReached] as cancel cause.
+//
+// Once success becomes impossible, others are canceled with [ErrNoQuorum] as cancel
+// cause, and a [MultiError] of failed tasks is returned.
+//
+// It returns without waiting canceled tasks. Take care of [NoCtx] and [NoErr]
+// tasks as it cannot be cancelled by context.
+func Quorum(k int, tasks ...Task) Task {
+	return func(ctx context.Context) error {
+		if k <= 0 {
+			return nil
+		}
+		if k > len(tasks) {
+			return ErrNoQuorum
+		}
+
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		ch := make(chan TaskError, len(tasks))
+		for idx, t := range tasks {
+			t.goChild(ctx, "quorum", idx, ch)
+		}
+
+		var errs MultiError
+		for succeeded := 0; ; {
+			e := <-ch
+			if e.Err == nil {
+				if succeeded++; succeeded >= k {
+					cancel(ErrQuorumReached)
+					return nil
+				}
+				continue
+			}
+
+			errs = append(errs, e)
+			if len(errs) > len(tasks)-k {
+				cancel(ErrNoQuorum)
+				sort.Slice(errs, func(i, j int) bool {
+					return errs[i].Index < errs[j].Index
+				})
+				return errs
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestQuorum(t *testing.T) {
+	errFail := errors.New("fail")
+	ok := func(_ context.Context) error { return nil }
+	fail := func(_ context.Context) error { return errFail }
+	causes := make(chan error, 3)
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return ctx.Err()
+	}
+
+	cases := []struct {
+		name   string
+		k      int
+		tasks  []Task
+		err    error
+		failed []int
+		cause  error
+	}{
+		{name: "reached", k: 2, tasks: []Task{ok, fail, block, ok}, cause: ErrQuorumReached},
+		{name: "lost", k: 3, tasks: []Task{fail, ok, block, fail}, err: errFail, failed: []int{0, 3}, cause: ErrNoQuorum},
+		{name: "zero", k: 0, tasks: []Task{fail}},
+		{name: "too many", k: 2, tasks: []Task{ok}, err: ErrNoQuorum},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := Quorum(c.k, c.tasks...).Run(context.Background())
+			if !errors.Is(err, c.err) || (c.err == nil && err != nil) {
+				t.Fatalf("expected %v, got %v", c.err, err)
+			}
+			if c.failed != nil {
+				var me MultiError
+				if !errors.As(err, &me) || len(me) != len(c.failed) {
+					t.Fatalf("expected failures at %v, got %v", c.failed, err)
+				}
+				for i, idx := range c.failed {
+					if me[i].Index != idx {
+						t.Fatalf("expected failures at %v, got %v", c.failed, me)
+					}
+				}
+			}
+			if c.cause != nil {
+				if cause := <-causes; cause != c.cause {
+					t.Fatalf("expected cause %v, got %v", c.cause, cause)
+				}
+			}
+		})
+	}
+}
@@ -19,9 +19,15 @@ import (
 // resulted data.
 func Data[T any](l *rate.Limiter, d action.Data[T]) action.Data[T] {
 	return func(ctx context.Context) (ret T, err error) {
-		reserve := l.Reserve()
-		if err = task.Sleep(reserve.Delay()).Run(ctx); err != nil {
-			reserve.Cancel()
+		clock := task.ClockFrom(ctx)
+		now := clock.Now()
+		reserve := l.ReserveN(now, 1)
+		wait := reserve.DelayFrom(now)
+		if wait > 0 {
+			task.ReportWait(ctx, wait)
+		}
+		if err = task.Sleep(wait).Run(ctx); err != nil {
+			reserve.CancelAt(clock.Now())
 			return
 		}
 
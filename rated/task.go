@@ -30,11 +30,19 @@ func New(l *rate.Limiter, t task.Task) (ret task.Task) {
 //
 //	r.Run() // executed immediately
 //	r.Run() // executed after a second
+//
+// Waiting uses the [task.Clock] carried by context.
 func Task(l *rate.Limiter, t task.Task) task.Task {
 	return func(ctx context.Context) error {
-		reserve := l.Reserve()
-		if err := task.Sleep(reserve.Delay()).Run(ctx); err != nil {
-			reserve.Cancel()
+		clock := task.ClockFrom(ctx)
+		now := clock.Now()
+		reserve := l.ReserveN(now, 1)
+		wait := reserve.DelayFrom(now)
+		if wait > 0 {
+			task.ReportWait(ctx, wait)
+		}
+		if err := task.Sleep(wait).Run(ctx); err != nil {
+			reserve.CancelAt(clock.Now())
 			return err
 		}
 
@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rated
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/tasktest"
+	"golang.org/x/time/rate"
+)
+
+func TestTaskClock(t *testing.T) {
+	clock := tasktest.NewClock(time.Unix(0, 0))
+	ctx := task.WithClock(context.Background(), clock)
+	l := rate.NewLimiter(rate.Every(time.Second), 1)
+	cnt := 0
+	rt := Task(l, task.NoErr(func() { cnt++ }))
+
+	if err := rt.Run(ctx); err != nil || cnt != 1 {
+		t.Fatalf("first run: cnt = %d, err = %v", cnt, err)
+	}
+
+	done := make(chan error)
+	go func() { done <- rt.Run(ctx) }()
+	clock.BlockUntil(1)
+	if cnt != 1 {
+		t.Fatal("second run is not limited")
+	}
+	clock.Advance(time.Second)
+	if err := <-done; err != nil || cnt != 2 {
+		t.Fatalf("second run: cnt = %d, err = %v", cnt, err)
+	}
+}
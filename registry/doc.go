@@ -0,0 +1,21 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package registry tracks running named tasks, and provides an [http.Handler] to
+// inspect and cancel them.
+//
+// [Registry] is a [task.Tracer], so it tracks tasks named by [task.Task.Named],
+// children of [task.Wait], [task.Skip] and [task.First], and tasks in
+// deptask.Runner. It is opt-in by carrying it through context:
+//
+//	reg := registry.New()
+//	http.Handle("/debug/tasks", reg)
+//	ctx := task.WithTracer(context.Background(), reg)
+//	err := myTask.Named("my task").Run(ctx)
+//
+// Use [task.MultiTracer] to use it with other tracers.
+//
+// The handler allows anyone reachable to cancel tasks, so it should be protected
+// like other debugging endpoints.
+package registry
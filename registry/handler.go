@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package registry
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var page = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html><head><title>Running tasks</title></head>
+<body>
+<h1>Running tasks</h1>
+{{if .}}{{template "list" .}}{{else}}<p>No running task.</p>{{end}}
+</body></html>
+{{define "list"}}<ul>{{range .}}
+<li>
+<form method="post"><input type="hidden" name="id" value="{{.ID}}">
+<b>{{.Name}}</b> #{{.ID}} {{.State}}{{if .Cause}} ({{.Cause}}){{end}},
+attempt {{.Attempt}}, started at {{.Start.Format "2006-01-02 15:04:05.000"}}
+{{if eq .State "running"}}<button type="submit">cancel</button>{{end}}
+</form>
+{{if .Children}}{{template "list" .Children}}{{end}}
+</li>{{end}}
+</ul>{{end}}
+`))
+
+// ServeHTTP implements [http.Handler].
+//
+// GET renders running tasks as a HTML page, or JSON if "format=json" is in query
+// string or the Accept header prefers "application/json".
+//
+// POST cancels the task identified by form value "id", and redirects to the same
+// path. It responds 404 if no such task.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		id, err := strconv.ParseUint(req.FormValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid task id", http.StatusBadRequest)
+			return
+		}
+		if !r.Cancel(id) {
+			http.NotFound(w, req)
+			return
+		}
+		http.Redirect(w, req, req.URL.Path, http.StatusSeeOther)
+		return
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := r.Snapshot()
+	if req.URL.Query().Get("format") == "json" ||
+		strings.HasPrefix(req.Header.Get("Accept"), "application/json") {
+		if entries == nil {
+			entries = []*Entry{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	page.Execute(w, entries)
+}
@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// ErrCanceled is the cancel cause of tasks canceled by [Registry.Cancel].
+var ErrCanceled = errors.New("canceled from task registry")
+
+// States of running tasks.
+const (
+	Running  = "running"
+	Canceled = "canceled"
+)
+
+// Entry is a snapshot of a running task.
+type Entry struct {
+	ID       uint64    `json:"id"`
+	Name     string    `json:"name"`
+	Parent   uint64    `json:"parent,omitempty"`
+	Start    time.Time `json:"start"`
+	Attempt  int       `json:"attempt"`
+	State    string    `json:"state"`
+	Cause    string    `json:"cause,omitempty"`
+	Children []*Entry  `json:"children,omitempty"`
+}
+
+// Registry tracks running named tasks. It is safe for concurrent use.
+type Registry struct {
+	lock   sync.Mutex
+	lastID uint64
+	nodes  map[uint64]*node
+}
+
+// New creates a Registry.
+func New() *Registry {
+	return &Registry{nodes: map[uint64]*node{}}
+}
+
+type nodeKey struct{}
+
+type node struct {
+	r       *Registry
+	id      uint64
+	parent  uint64
+	name    string
+	start   time.Time
+	attempt int
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+}
+
+// Start implements [task.Tracer]. The task runs with a cancellable context, so it
+// can be canceled by [Registry.Cancel].
+func (r *Registry) Start(ctx context.Context, name string) (context.Context, task.Span) {
+	n := &node{
+		r:       r,
+		name:    name,
+		start:   task.ClockFrom(ctx).Now(),
+		attempt: task.Attempt(ctx),
+	}
+	if p, ok := ctx.Value(nodeKey{}).(*node); ok && p.r == r {
+		n.parent = p.id
+	}
+	n.ctx, n.cancel = context.WithCancelCause(ctx)
+
+	r.lock.Lock()
+	r.lastID++
+	n.id = r.lastID
+	r.nodes[n.id] = n
+	r.lock.Unlock()
+
+	return context.WithValue(n.ctx, nodeKey{}, n), n
+}
+
+// End implements [task.Span].
+func (n *node) End(_ error) {
+	n.r.lock.Lock()
+	delete(n.r.nodes, n.id)
+	n.r.lock.Unlock()
+	n.cancel(nil)
+}
+
+// Cancel cancels the running task with id, and its children, with [ErrCanceled] as
+// cancel cause. It returns false if no such task.
+func (r *Registry) Cancel(id uint64) bool {
+	r.lock.Lock()
+	n, ok := r.nodes[id]
+	r.lock.Unlock()
+	if ok {
+		n.cancel(ErrCanceled)
+	}
+	return ok
+}
+
+// Snapshot returns running tasks as trees, sorted by id. Tasks which parent is
+// finished are treated as roots.
+func (r *Registry) Snapshot() []*Entry {
+	r.lock.Lock()
+	entries := make(map[uint64]*Entry, len(r.nodes))
+	for id, n := range r.nodes {
+		e := &Entry{
+			ID:      id,
+			Name:    n.name,
+			Parent:  n.parent,
+			Start:   n.start,
+			Attempt: n.attempt,
+			State:   Running,
+		}
+		if n.ctx.Err() != nil {
+			e.State = Canceled
+			e.Cause = context.Cause(n.ctx).Error()
+		}
+		entries[id] = e
+	}
+	r.lock.Unlock()
+
+	ids := make([]uint64, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var roots []*Entry
+	for _, id := range ids {
+		e := entries[id]
+		if p, ok := entries[e.Parent]; ok {
+			p.Children = append(p.Children, e)
+			continue
+		}
+		roots = append(roots, e)
+	}
+	return roots
+}
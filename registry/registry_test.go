@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/tasktest"
+)
+
+// waitFor polls reg until f returns true.
+func waitFor(t *testing.T, reg *Registry, f func([]*Entry) bool) []*Entry {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if e := reg.Snapshot(); f(e) {
+			return e
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timeout waiting for registry")
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	reg := New()
+	ctx := task.WithTracer(context.Background(), reg)
+	causes := make(chan error, 1)
+	block := tasktest.Block()
+
+	done := task.Task(task.Wait(
+		block.Named("a"),
+		task.Task(func(ctx context.Context) error {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return nil
+		}).Named("b"),
+	)).Named("root").Go(ctx)
+
+	roots := waitFor(t, reg, func(e []*Entry) bool {
+		return len(e) == 1 && len(e[0].Children) == 2 &&
+			len(e[0].Children[0].Children) == 1 && len(e[0].Children[1].Children) == 1
+	})
+	if roots[0].Name != "root" || roots[0].State != Running || roots[0].Attempt != 1 {
+		t.Fatalf("unexpected root: %+v", roots[0])
+	}
+
+	// find b
+	var b *Entry
+	for _, w := range roots[0].Children {
+		if c := w.Children[0]; c.Name == "b" {
+			b = c
+		}
+	}
+	if b == nil {
+		t.Fatalf("b is not found: %+v", roots[0].Children)
+	}
+
+	srv := httptest.NewServer(reg)
+	defer srv.Close()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.PostForm(srv.URL, url.Values{"id": {strconv.FormatUint(b.ID, 10)}})
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSeeOther {
+		t.Fatal("unexpected status: ", resp.Status)
+	}
+	if cause := <-causes; cause != ErrCanceled {
+		t.Fatal("unexpected cause: ", cause)
+	}
+
+	// a is still running
+	resp, err = http.Get(srv.URL + "?format=json")
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	var entries []*Entry
+	err = json.NewDecoder(resp.Body).Decode(&entries)
+	resp.Body.Close()
+	if err != nil || len(entries) != 1 || entries[0].Name != "root" {
+		t.Fatalf("unexpected json: %+v, %v", entries, err)
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	buf := &bytes.Buffer{}
+	buf.ReadFrom(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(buf.String(), "<b>a</b>") {
+		t.Fatalf("unexpected html: %s", buf)
+	}
+
+	reg.Cancel(roots[0].ID)
+	if err := <-done; err != ErrCanceled {
+		t.Fatal("unexpected error: ", err)
+	}
+	if e := reg.Snapshot(); len(e) != 0 {
+		t.Fatalf("expected no running task, got %+v", e)
+	}
+
+	resp, err = client.PostForm(srv.URL, url.Values{"id": {"12345"}})
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatal("unexpected status: ", resp.Status)
+	}
+}
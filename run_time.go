@@ -11,9 +11,10 @@ import (
 
 func (t Task) timed(dur func(time.Duration) time.Duration, e func(error) bool) Task {
 	return func(ctx context.Context) error {
-		begin := time.Now()
+		clock := ClockFrom(ctx)
+		begin := clock.Now()
 		err := t.Run(ctx)
-		wait := dur(time.Since(begin))
+		wait := dur(clock.Since(begin))
 		if wait > 0 && e(err) {
 			er := Sleep(wait).Run(ctx)
 			if err == nil {
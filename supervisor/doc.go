@@ -0,0 +1,20 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package supervisor provides Erlang-style supervisor to keep long-running tasks
+// alive.
+//
+// A [Supervisor] runs child tasks, and restarts them according to its [Strategy]
+// and their [Restart] policy when they exit. Supervisor itself is a [task.Task],
+// so it can be a child of another supervisor to build a supervision tree:
+//
+//	workers := supervisor.New(supervisor.Config{Strategy: supervisor.OneForOne})
+//	workers.Add("consumer", supervisor.Permanent, consumer)
+//	workers.Add("cleanup", supervisor.Transient, cleanup)
+//
+//	root := supervisor.New(supervisor.Config{Strategy: supervisor.OneForAll})
+//	root.Add("http", supervisor.Permanent, httptask.Server(srv))
+//	root.Add("workers", supervisor.Permanent, workers.Task())
+//	err := root.Task().Run(ctx)
+package supervisor
@@ -0,0 +1,279 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// ErrTooManyRestarts is returned by the supervisor if restart intensity exceeds
+// the limit. It is wrapped with the error of last failed child.
+var ErrTooManyRestarts = errors.New("supervisor: too many restarts")
+
+// Strategy defines which children are restarted when a child exits.
+type Strategy int
+
+const (
+	// OneForOne restarts only the exited child.
+	OneForOne Strategy = iota
+	// OneForAll stops all other children, and restarts all of them.
+	OneForAll
+	// RestForOne stops children added after the exited child, and restarts
+	// the exited child and them.
+	RestForOne
+)
+
+// Restart defines if a child should be restarted when it exits.
+type Restart int
+
+const (
+	// Permanent child is always restarted.
+	Permanent Restart = iota
+	// Transient child is restarted only if it returns an error.
+	Transient
+	// Temporary child is never restarted, even if it is stopped by the
+	// supervisor because of other children.
+	Temporary
+)
+
+// Config configures a [Supervisor].
+type Config struct {
+	Strategy Strategy
+	// The supervisor stops all children and fails with [ErrTooManyRestarts] if
+	// there're more than MaxRestarts restarts in Period. Default to 3 restarts
+	// in 5 seconds.
+	MaxRestarts int
+	Period      time.Duration
+	// Computes delay before restarting a child. n passed to it is the count of
+	// consecutive restarts of the child, which is reset if the child has run
+	// for more than Period. nil means no delay.
+	Backoff task.BackoffPolicy
+}
+
+type child struct {
+	name    string
+	restart Restart
+	task    task.Task
+
+	gen      int
+	cancel   context.CancelFunc
+	done     chan struct{}
+	running  bool
+	removed  bool
+	begin    time.Time
+	restarts int
+	prev     time.Duration
+}
+
+type exit struct {
+	idx, gen int
+	err      error
+}
+
+// Supervisor runs children and restarts them when they exit.
+//
+// Zero value is not usable, use [New] to create one.
+type Supervisor struct {
+	cfg      Config
+	children []*child
+	history  []time.Time
+	exits    chan exit
+	quit     chan struct{}
+}
+
+// New creates a Supervisor.
+func New(cfg Config) *Supervisor {
+	if cfg.MaxRestarts <= 0 {
+		cfg.MaxRestarts = 3
+	}
+	if cfg.Period <= 0 {
+		cfg.Period = 5 * time.Second
+	}
+	return &Supervisor{cfg: cfg}
+}
+
+// Add adds a child. Children are started in the order they are added, and
+// stopped in reverse order.
+//
+// It MUST NOT be called after the supervisor is started.
+func (s *Supervisor) Add(name string, restart Restart, t task.Task) {
+	s.children = append(s.children, &child{name: name, restart: restart, task: t})
+}
+
+// start runs a child in separated goroutine after waiting delay.
+func (s *Supervisor) start(ctx context.Context, idx int, delay time.Duration) {
+	c := s.children[idx]
+	c.gen++
+	c.running = true
+	c.done = make(chan struct{})
+	c.begin = time.Now().Add(delay)
+	ctx, c.cancel = context.WithCancel(ctx)
+
+	run := c.task
+	if task.RecoverByDefault() {
+		run = run.Recover()
+	}
+	if delay > 0 {
+		run = task.Sleep(delay).Then(run)
+	}
+	// channels are captured as they are replaced when the supervisor is run
+	// again, which might happen before this goroutine exits
+	gen, done, exits, quit := c.gen, c.done, s.exits, s.quit
+	go func() {
+		err := run.Run(ctx)
+		close(done)
+		select {
+		case exits <- exit{idx: idx, gen: gen, err: err}:
+		case <-quit:
+		}
+	}()
+}
+
+// stop cancels running children in reverse order, and waits them to exit.
+func (s *Supervisor) stop(idxs []int) {
+	for i := len(idxs) - 1; i >= 0; i-- {
+		c := s.children[idxs[i]]
+		if !c.running {
+			continue
+		}
+		c.cancel()
+		<-c.done
+		c.running = false
+		c.gen++
+	}
+}
+
+func (s *Supervisor) all(from int) (ret []int) {
+	for idx := from; idx < len(s.children); idx++ {
+		if !s.children[idx].removed {
+			ret = append(ret, idx)
+		}
+	}
+	return
+}
+
+// shouldRestart decides if a child should be restarted, and removes it if not.
+func (c *child) shouldRestart(err error, stoppedBySupervisor bool) bool {
+	switch {
+	case c.restart == Temporary:
+	case c.restart == Transient && err == nil && !stoppedBySupervisor:
+	default:
+		return true
+	}
+	c.removed = true
+	return false
+}
+
+func (s *Supervisor) delay(c *child, now time.Time) time.Duration {
+	if now.Sub(c.begin) > s.cfg.Period {
+		c.restarts, c.prev = 0, 0
+	}
+	c.restarts++
+	if s.cfg.Backoff == nil {
+		return 0
+	}
+	d, ok := s.cfg.Backoff(c.restarts, c.prev, 0)
+	if !ok {
+		d = c.prev
+	}
+	c.prev = d
+	return d
+}
+
+// exceeded records a restart and checks restart intensity.
+func (s *Supervisor) exceeded(now time.Time) bool {
+	s.history = append(s.history, now)
+	idx := 0
+	for idx < len(s.history) && now.Sub(s.history[idx]) > s.cfg.Period {
+		idx++
+	}
+	s.history = s.history[idx:]
+	return len(s.history) > s.cfg.MaxRestarts
+}
+
+// handle processes an exited child, and reports error if the supervisor should
+// stop.
+func (s *Supervisor) handle(ctx context.Context, e exit) error {
+	c := s.children[e.idx]
+	if e.gen != c.gen {
+		// stopped by supervisor, already handled
+		return nil
+	}
+	c.running = false
+	if !c.shouldRestart(e.err, false) {
+		return nil
+	}
+
+	now := time.Now()
+	if s.exceeded(now) {
+		err := ErrTooManyRestarts
+		if e.err != nil {
+			err = fmt.Errorf("%w: child %s: %w", ErrTooManyRestarts, c.name, e.err)
+		}
+		return err
+	}
+
+	var affected []int
+	switch s.cfg.Strategy {
+	case OneForAll:
+		affected = s.all(0)
+	case RestForOne:
+		affected = s.all(e.idx)
+	default:
+		affected = []int{e.idx}
+	}
+
+	s.stop(affected)
+	for _, idx := range affected {
+		x := s.children[idx]
+		if idx != e.idx && !x.shouldRestart(nil, true) {
+			continue
+		}
+		s.start(ctx, idx, s.delay(x, now))
+	}
+	return nil
+}
+
+// Task creates a task that runs the supervisor. It returns when
+//
+//   - the context is canceled, returns the context error.
+//   - restart intensity exceeds the limit, returns [ErrTooManyRestarts].
+//   - no child is running and none will be restarted, returns nil.
+//
+// All children are stopped before returning. The task MUST NOT be run
+// concurrently.
+func (s *Supervisor) Task() task.Task {
+	return func(ctx context.Context) (err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		s.exits = make(chan exit)
+		s.quit = make(chan struct{})
+		s.history = nil
+		defer close(s.quit)
+		defer func() { s.stop(s.all(0)) }()
+
+		for idx, c := range s.children {
+			c.removed, c.restarts, c.prev = false, 0, 0
+			s.start(ctx, idx, 0)
+		}
+
+		for len(s.all(0)) > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case e := <-s.exits:
+				if err = s.handle(ctx, e); err != nil {
+					return
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+type recorder struct {
+	sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(ev string) {
+	r.Lock()
+	defer r.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *recorder) count(ev string) (ret int) {
+	r.Lock()
+	defer r.Unlock()
+	for _, e := range r.events {
+		if e == ev {
+			ret++
+		}
+	}
+	return
+}
+
+// blocker records start and stop, and runs until canceled.
+func (r *recorder) blocker(name string) task.Task {
+	return func(ctx context.Context) error {
+		r.add("start " + name)
+		<-ctx.Done()
+		r.add("stop " + name)
+		return ctx.Err()
+	}
+}
+
+// failer fails n times, then runs until canceled.
+func (r *recorder) failer(name string, n int) task.Task {
+	cnt := 0
+	return func(ctx context.Context) error {
+		r.add("start " + name)
+		cnt++
+		if cnt <= n {
+			return errors.New("fail")
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+}
+
+func runFor(s *Supervisor, d time.Duration) error {
+	return s.Task().With(task.Timeout(d)).Run(context.Background())
+}
+
+func TestStrategies(t *testing.T) {
+	cases := []struct {
+		strategy Strategy
+		expectA  int
+		expectC  int
+	}{
+		{OneForOne, 1, 1},
+		{OneForAll, 3, 3},
+		{RestForOne, 1, 3},
+	}
+
+	for _, c := range cases {
+		r := &recorder{}
+		s := New(Config{Strategy: c.strategy, MaxRestarts: 5})
+		s.Add("a", Permanent, r.blocker("a"))
+		s.Add("b", Permanent, r.failer("b", 2))
+		s.Add("c", Permanent, r.blocker("c"))
+
+		if err := runFor(s, 100*time.Millisecond); err != context.DeadlineExceeded {
+			t.Fatalf("strategy %d: unexpected error: %v", c.strategy, err)
+		}
+		if n := r.count("start a"); n != c.expectA {
+			t.Errorf("strategy %d: expected a to start %d times, got %d", c.strategy, c.expectA, n)
+		}
+		if n := r.count("start b"); n != 3 {
+			t.Errorf("strategy %d: expected b to start 3 times, got %d", c.strategy, n)
+		}
+		if n := r.count("start c"); n != c.expectC {
+			t.Errorf("strategy %d: expected c to start %d times, got %d", c.strategy, c.expectC, n)
+		}
+		if r.count("stop a") != r.count("start a") || r.count("stop c") != r.count("start c") {
+			t.Errorf("strategy %d: children are not stopped: %v", c.strategy, r.events)
+		}
+	}
+}
+
+func TestIntensity(t *testing.T) {
+	r := &recorder{}
+	s := New(Config{MaxRestarts: 2, Period: time.Minute})
+	s.Add("a", Permanent, r.blocker("a"))
+	s.Add("b", Permanent, r.failer("b", 10))
+
+	err := runFor(s, time.Second)
+	if !errors.Is(err, ErrTooManyRestarts) {
+		t.Fatal("unexpected error:", err)
+	}
+	if n := r.count("start b"); n != 3 {
+		t.Fatal("expected b to start 3 times, got", n)
+	}
+	if r.count("stop a") != 1 {
+		t.Fatal("a is not stopped")
+	}
+}
+
+func TestRestartPolicy(t *testing.T) {
+	r := &recorder{}
+	s := New(Config{})
+	s.Add("transient", Transient, r.failer("transient", 1).
+		With(task.Timeout(time.Millisecond)).
+		IgnoreErrs(task.ContextError))
+	s.Add("temporary", Temporary, r.failer("temporary", 1))
+
+	if err := runFor(s, time.Second); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if n := r.count("start transient"); n != 2 {
+		t.Error("expected transient to start 2 times, got", n)
+	}
+	if n := r.count("start temporary"); n != 1 {
+		t.Error("expected temporary to start once, got", n)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	r := &recorder{}
+	s := New(Config{Backoff: task.ConstantBackoff(50 * time.Millisecond)})
+	s.Add("a", Permanent, r.failer("a", 1))
+
+	runFor(s, 30*time.Millisecond)
+	if n := r.count("start a"); n != 1 {
+		t.Fatal("expected restart to be delayed, got", n)
+	}
+}
+
+func TestNested(t *testing.T) {
+	r := &recorder{}
+	inner := New(Config{})
+	inner.Add("a", Permanent, r.blocker("a"))
+	outer := New(Config{})
+	outer.Add("inner", Permanent, inner.Task())
+
+	runFor(outer, 10*time.Millisecond)
+	if r.count("start a") != 1 || r.count("stop a") != 1 {
+		t.Fatal("unexpected events:", r.events)
+	}
+}
+
+func TestNestedRestart(t *testing.T) {
+	// runs sequentially, no need to lock
+	runs := 0
+	fail := task.NoCtx(func() error { return errors.New("fail") })
+	inner := New(Config{MaxRestarts: 1})
+	inner.Add("a", Permanent, fail)
+	inner.Add("b", Permanent, fail)
+	outer := New(Config{MaxRestarts: 3})
+	outer.Add("inner", Permanent, task.Task(func(ctx context.Context) error {
+		runs++
+		return inner.Task().Run(ctx)
+	}))
+
+	err := outer.Task().Run(context.Background())
+	if !errors.Is(err, ErrTooManyRestarts) {
+		t.Fatal("unexpected error:", err)
+	}
+	if runs != 4 {
+		t.Fatal("expected inner supervisor to be run 4 times, got", runs)
+	}
+}
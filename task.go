@@ -46,7 +46,10 @@ func (t Task) Go(ctx context.Context) <-chan error {
 }
 
 // GoWithChan runs t in separated goroutine and sends returned error into ch.
+//
+// Panics are recovered if [SetRecoverByDefault] is enabled.
 func (t Task) GoWithChan(ctx context.Context, ch chan<- error) {
+	t = t.recoverIfDefault()
 	go func() { ch <- t.Run(ctx) }()
 }
 
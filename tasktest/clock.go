@@ -0,0 +1,147 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// Clock is a [task.Clock] which moves only when [Clock.Advance] or [Clock.Set] is
+// called. It is safe for concurrent use.
+type Clock struct {
+	lock   sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*timer
+}
+
+// NewClock creates a Clock starts at now.
+func NewClock(now time.Time) *Clock {
+	ret := &Clock{now: now}
+	ret.cond = sync.NewCond(&ret.lock)
+	return ret
+}
+
+// Now implements [task.Clock].
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// Since implements [task.Clock].
+func (c *Clock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+// After implements [task.Clock].
+func (c *Clock) After(d time.Duration) <-chan time.Time { return c.NewTimer(d).C() }
+
+// NewTimer implements [task.Clock].
+func (c *Clock) NewTimer(d time.Duration) task.Timer {
+	ret := &timer{clock: c, ch: make(chan time.Time, 1)}
+	ret.Reset(d)
+	return ret
+}
+
+// Advance moves the clock forward by d, and fires timers which are expired.
+func (c *Clock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set sets the clock to t, and fires timers which are expired. Setting to a time
+// before current time is allowed, but no timer is fired.
+func (c *Clock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.set(t)
+}
+
+func (c *Clock) set(t time.Time) {
+	c.now = t
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+	n := 0
+	for _, x := range c.timers {
+		if x.at.After(t) {
+			c.timers[n] = x
+			n++
+			continue
+		}
+		x.fire(t)
+	}
+	clear(c.timers[n:])
+	c.timers = c.timers[:n]
+}
+
+// Sleepers returns number of pending timers, which are usually created by
+// sleeping tasks.
+func (c *Clock) Sleepers() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil blocks until there are at least n pending timers. It is used to
+// ensure tasks are sleeping before calling [Clock.Advance].
+func (c *Clock) BlockUntil(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *Clock) remove(t *timer) bool {
+	for i, x := range c.timers {
+		if x == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type timer struct {
+	clock *Clock
+	ch    chan time.Time
+	at    time.Time
+}
+
+func (t *timer) fire(now time.Time) {
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+func (t *timer) C() <-chan time.Time { return t.ch }
+
+func (t *timer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *timer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ret := c.remove(t)
+	if d <= 0 {
+		t.fire(c.now)
+		return ret
+	}
+
+	t.at = c.now.Add(d)
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return ret
+}
@@ -0,0 +1,32 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+func ExampleClock() {
+	clock := NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := task.WithClock(context.Background(), clock)
+	t := task.NoErr(func() { fmt.Println("run at", clock.Now().Format("15:04:05")) })
+
+	done := make(chan error)
+	go func() { done <- t.Timed(time.Minute).Then(t).Run(ctx) }()
+
+	clock.BlockUntil(1) // first run is done, waiting for a minute
+	fmt.Println("sleepers:", clock.Sleepers())
+	clock.Advance(time.Minute)
+	fmt.Println(<-done)
+
+	// output: run at 00:00:00
+	// sleepers: 1
+	// run at 00:01:00
+	// <nil>
+}
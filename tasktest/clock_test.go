@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+func TestClockTimer(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	a := clock.NewTimer(2 * time.Second)
+	b := clock.NewTimer(time.Second)
+	if n := clock.Sleepers(); n != 2 {
+		t.Fatalf("expected 2 sleepers, got %d", n)
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-a.C():
+		t.Fatal("a fired too early")
+	case now := <-b.C():
+		if !now.Equal(time.Unix(1, 0)) {
+			t.Fatalf("unexpected fire time: %v", now)
+		}
+	}
+
+	if !a.Stop() {
+		t.Fatal("expected a to be active")
+	}
+	if a.Stop() {
+		t.Fatal("expected a to be stopped")
+	}
+	clock.Advance(time.Hour)
+	select {
+	case <-a.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	if a.Reset(0) {
+		t.Fatal("expected a to be inactive")
+	}
+	select {
+	case <-a.C():
+	default:
+		t.Fatal("expected timer to be fired immediately")
+	}
+}
+
+func TestClockSleepCancel(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(task.WithClock(context.Background(), clock))
+	done := make(chan error)
+	go func() { done <- task.Sleep(time.Hour).Run(ctx) }()
+
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := clock.Sleepers(); n != 0 {
+		t.Fatalf("expected timer to be stopped, got %d sleepers", n)
+	}
+}
+
+func TestClockRetryWith(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	ctx := task.WithClock(context.Background(), clock)
+	var elapsed []time.Duration
+	p := task.BackoffPolicy(func(n int, _, e time.Duration) (time.Duration, bool) {
+		elapsed = append(elapsed, e)
+		return time.Second, n < 3
+	})
+
+	done := make(chan error)
+	go func() { done <- task.NoCtx(func() error { return errors.New("failed") }).RetryWith(p).Run(ctx) }()
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	<-done
+
+	expect := []time.Duration{0, time.Second, 2 * time.Second}
+	if len(elapsed) != len(expect) {
+		t.Fatalf("expected %v, got %v", expect, elapsed)
+	}
+	for i, e := range expect {
+		if elapsed[i] != e {
+			t.Fatalf("expected %v, got %v", expect, elapsed)
+		}
+	}
+}
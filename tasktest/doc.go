@@ -0,0 +1,19 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package tasktest provides helpers to test code built on package task.
+//
+// [FailN], [Block] and [BlockUntil] build tasks with predictable behavior.
+// [Recorder] records how tasks are invoked, and [CheckLeak] ensures goroutines
+// spawned by tasks do not outlive the test.
+//
+// [Clock] is a manual [task.Clock], so time-based tasks like [task.Sleep],
+// [task.Task.Timed] or rated tasks can be tested without real waiting:
+//
+//	clock := tasktest.NewClock(time.Now())
+//	ctx := task.WithClock(context.Background(), clock)
+//	go myTimedTask.Run(ctx)
+//	clock.BlockUntil(1)        // wait until myTimedTask sleeps
+//	clock.Advance(time.Second) // wake it up
+package tasktest
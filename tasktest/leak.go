@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// LeakGrace is how long [CheckLeak] waits for goroutines to exit before reporting
+// them.
+const LeakGrace = time.Second
+
+// goroutines which live forever once created.
+var leakIgnored = []string{
+	"os/signal.loop",
+	"os/signal.signal_recv",
+}
+
+// CheckLeak reports an error if goroutines created after calling it are still
+// alive when the test finishes, like tasks started by [task.First] or
+// [task.Task.Go] but never waited. Goroutines are given [LeakGrace] to exit.
+//
+// Call it at the beginning of a test. It cannot be used with parallel tests, as
+// goroutines created by other tests are reported too.
+func CheckLeak(t testing.TB) {
+	t.Helper()
+	before := goroutines()
+	t.Cleanup(func() {
+		var leaked []string
+		deadline := time.Now().Add(LeakGrace)
+		for {
+			leaked = leaked[:0]
+			for id, stack := range goroutines() {
+				if _, ok := before[id]; !ok {
+					leaked = append(leaked, stack)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if len(leaked) > 0 {
+			t.Errorf(
+				"%d goroutine(s) leaked:\n\n%s",
+				len(leaked), strings.Join(leaked, "\n\n"),
+			)
+		}
+	})
+}
+
+// goroutines returns stacks of all goroutines except current one, keyed by id.
+func goroutines() map[string]string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	ret := map[string]string{}
+	// first one is current goroutine
+	for _, g := range bytes.Split(buf, []byte("\n\n"))[1:] {
+		stack := string(g)
+		id, _, _ := strings.Cut(stack, " [")
+		if id == "" || ignored(stack) {
+			continue
+		}
+		ret[id] = stack
+	}
+	return ret
+}
+
+func ignored(stack string) bool {
+	for _, s := range leakIgnored {
+		if strings.Contains(stack, s) {
+			return true
+		}
+	}
+	return false
+}
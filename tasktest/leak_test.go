@@ -0,0 +1,58 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/raohwork/task"
+)
+
+// fakeT records errors and cleanup functions.
+type fakeT struct {
+	testing.TB
+	errs     []string
+	cleanups []func()
+}
+
+func (t *fakeT) Helper()          {}
+func (t *fakeT) Cleanup(f func()) { t.cleanups = append(t.cleanups, f) }
+func (t *fakeT) Errorf(f string, args ...any) {
+	t.errs = append(t.errs, f)
+}
+
+func (t *fakeT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestCheckLeak(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ft := &fakeT{}
+	CheckLeak(ft)
+	err := task.First(Block(), task.NoErr(func() {})).Run(ctx)
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	ft.finish()
+	if len(ft.errs) != 0 {
+		t.Fatal("unexpected leak: ", ft.errs)
+	}
+
+	ft = &fakeT{}
+	CheckLeak(ft)
+	stop := make(chan struct{})
+	defer close(stop)
+	task.Task(BlockUntil(stop)).Go(ctx)
+	ft.finish()
+	if len(ft.errs) != 1 || !strings.Contains(ft.errs[0], "leaked") {
+		t.Fatal("expected a leak, got ", ft.errs)
+	}
+}
@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/raohwork/task"
+)
+
+// Recorder records invocations of tasks: the order they begin, how many times
+// each of them runs, and how many of them run at the same time.
+//
+// Zero value is ready to use. It is safe for concurrent use.
+type Recorder struct {
+	lock    sync.Mutex
+	calls   []string
+	running int
+	max     int
+}
+
+// Record wraps t into a task which is recorded as name. Nil t is treated as a
+// task that does nothing.
+func (r *Recorder) Record(name string, t task.Task) task.Task {
+	return func(ctx context.Context) error {
+		r.begin(name)
+		defer r.end()
+		if t == nil {
+			return nil
+		}
+		return t.Run(ctx)
+	}
+}
+
+// Middleware creates a [task.Middleware] which applies [Recorder.Record].
+func (r *Recorder) Middleware(name string) task.Middleware {
+	return func(t task.Task) task.Task { return r.Record(name, t) }
+}
+
+func (r *Recorder) begin(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls = append(r.calls, name)
+	r.running++
+	if r.running > r.max {
+		r.max = r.running
+	}
+}
+
+func (r *Recorder) end() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.running--
+}
+
+// Calls returns names of recorded tasks in the order they begin.
+func (r *Recorder) Calls() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// Count returns how many times the task named name has begun.
+func (r *Recorder) Count(name string) (ret int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, c := range r.calls {
+		if c == name {
+			ret++
+		}
+	}
+	return
+}
+
+// MaxParallel returns the maximum number of recorded tasks running at the same
+// time.
+func (r *Recorder) MaxParallel() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.max
+}
+
+// Running returns the number of recorded tasks which are running.
+func (r *Recorder) Running() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.running
+}
+
+// Reset clears recorded data. It should not be called when recorded tasks are
+// running.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls, r.running, r.max = nil, 0, 0
+}
+
+// AssertCount reports an error if the task named name has not begun n times.
+func (r *Recorder) AssertCount(t testing.TB, name string, n int) {
+	t.Helper()
+	if c := r.Count(name); c != n {
+		t.Errorf("expected %s to be run %d times, got %d", name, n, c)
+	}
+}
+
+// AssertOrder reports an error if recorded tasks have not begun exactly in the
+// order of names.
+func (r *Recorder) AssertOrder(t testing.TB, names ...string) {
+	t.Helper()
+	if c := r.Calls(); !slices.Equal(c, names) {
+		t.Errorf("expected calls %q, got %q", names, c)
+	}
+}
+
+// AssertMaxParallel reports an error if more than n recorded tasks have run at the
+// same time.
+func (r *Recorder) AssertMaxParallel(t testing.TB, n int) {
+	t.Helper()
+	if m := r.MaxParallel(); m > n {
+		t.Errorf("expected at most %d tasks running at the same time, got %d", n, m)
+	}
+}
@@ -0,0 +1,34 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/raohwork/task"
+)
+
+func ExampleRecorder() {
+	var r Recorder
+	flaky := r.Record("flaky", FailN(2, errors.New("failed")))
+	stable := r.Record("stable", nil)
+	err := task.Wait(
+		flaky.RetryN(5),
+		stable,
+		stable,
+	).Run(context.Background())
+
+	fmt.Println(err)
+	fmt.Println("flaky:", r.Count("flaky"))
+	fmt.Println("stable:", r.Count("stable"))
+	fmt.Println("parallel:", r.MaxParallel() <= 3)
+
+	// output: <nil>
+	// flaky: 3
+	// stable: 2
+	// parallel: true
+}
@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tasktest
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/raohwork/task"
+)
+
+// FailN creates a task that returns err in first n runs, and nil after that. It
+// is safe to run concurrently.
+func FailN(n int, err error) task.Task {
+	var cnt atomic.Int64
+	return func(_ context.Context) error {
+		if cnt.Add(1) <= int64(n) {
+			return err
+		}
+		return nil
+	}
+}
+
+// Block creates a task that blocks until the context is canceled, and returns the
+// cause.
+func Block() task.Task {
+	return func(ctx context.Context) error {
+		<-ctx.Done()
+		return context.Cause(ctx)
+	}
+}
+
+// BlockUntil creates a task that blocks until ch is closed or the context is
+// canceled. It is useful to control when a task finishes.
+func BlockUntil(ch <-chan struct{}) task.Task {
+	return func(ctx context.Context) error {
+		select {
+		case <-ch:
+			return nil
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"runtime/debug"
+	"runtime/pprof"
+	"strconv"
+)
+
+// Span is an execution of a named task, created by [Tracer].
+type Span interface {
+	// End is called with the result when the execution finishes.
+	End(err error)
+}
+
+// Tracer receives hooks when named tasks are executed, see [Task.Named].
+//
+// The tracer is carried through context, see [WithTracer]. Package tracing
+// provides a Tracer which exports spans in Chrome trace-event format.
+type Tracer interface {
+	// Start is called before running a task named name. Returned context is
+	// passed to the task, so spans of nested tasks can be linked to it.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type tracerKey struct{}
+
+// WithTracer creates a context which carries tr. Named tasks running with it are
+// traced by tr.
+func WithTracer(ctx context.Context, tr Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tr)
+}
+
+// TracerFrom retrieves the [Tracer] carried by ctx, or nil if none.
+func TracerFrom(ctx context.Context) Tracer {
+	tr, _ := ctx.Value(tracerKey{}).(Tracer)
+	return tr
+}
+
+// MultiTracer creates a [Tracer] which dispatches hooks to all of trs.
+func MultiTracer(trs ...Tracer) Tracer { return multiTracer(trs) }
+
+type multiTracer []Tracer
+
+func (m multiTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	spans := make(multiSpan, len(m))
+	for idx, tr := range m {
+		ctx, spans[idx] = tr.Start(ctx, name)
+	}
+	return ctx, spans
+}
+
+type multiSpan []Span
+
+func (m multiSpan) End(err error) {
+	for i := len(m) - 1; i >= 0; i-- {
+		m[i].End(err)
+	}
+}
+
+// Named creates a task that runs t as a span named name, if there's a [Tracer]
+// carried by context.
+//
+// t runs under [pprof.Do] with label "task" set to name, so profiles can be
+// filtered by task name. Goroutines spawned by t, like children of [Wait], inherit
+// the label.
+//
+// Children of combinators like [Wait], [Skip], [First] and [Group] are named
+// automatically like "wait#0". They are traced but not labeled, so the label of
+// parent is kept. If such child is named by you, the name is reported in
+// [TaskError].
+func (t Task) Named(name string) Task {
+	traced := t.traced(name)
+	labels := pprof.Labels("task", name)
+	return func(ctx context.Context) (err error) {
+		ctx = fillName(ctx, name)
+		pprof.Do(ctx, labels, func(ctx context.Context) {
+			err = traced(ctx)
+		})
+		return
+	}
+}
+
+func (t Task) traced(name string) Task {
+	return func(ctx context.Context) error {
+		tr := TracerFrom(ctx)
+		if tr == nil {
+			return t.Run(ctx)
+		}
+
+		ctx, span := tr.Start(ctx, name)
+		defer func() {
+			if v := recover(); v != nil {
+				span.End(&PanicError{Value: v, Stack: debug.Stack()})
+				panic(v)
+			}
+		}()
+		err := t.Run(ctx)
+		span.End(err)
+		return err
+	}
+}
+
+// child names idx-th child of a combinator. Panics are recovered inside the span
+// if [SetRecoverByDefault] is enabled, so it should not be passed to GoWithChan,
+// which recovers again.
+func (t Task) child(kind string, idx int) Task {
+	return t.recoverIfDefault().traced(kind + "#" + strconv.Itoa(idx))
+}
+
+// goChild runs t as idx-th child of a combinator in separated goroutine, and sends
+// returned error with idx and name into ch.
+func (t Task) goChild(ctx context.Context, kind string, idx int, ch chan<- TaskError) {
+	t = t.child(kind, idx)
+	go func() {
+		ctx, name := withNameSlot(ctx)
+		err := t.Run(ctx)
+		ch <- TaskError{Index: idx, Name: name(), Err: err}
+	}()
+}
@@ -0,0 +1,102 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type logTracer struct {
+	id   string
+	logs *[]string
+}
+
+type logSpan logTracer
+
+func (t logTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	*t.logs = append(*t.logs, t.id+" start "+name)
+	return ctx, logSpan(t)
+}
+
+func (s logSpan) End(err error) {
+	*s.logs = append(*s.logs, s.id+" end "+err.Error())
+}
+
+func TestMultiTracer(t *testing.T) {
+	var logs []string
+	tr := MultiTracer(logTracer{"a", &logs}, logTracer{"b", &logs})
+	ctx := WithTracer(context.Background(), tr)
+
+	NoCtx(func() error { return errors.New("x") }).Named("t").Run(ctx)
+	expect := []string{"a start t", "b start t", "b end x", "a end x"}
+	if !slices.Equal(logs, expect) {
+		t.Fatalf("expected %q, got %q", expect, logs)
+	}
+}
+
+type nameTracer struct {
+	lock  sync.Mutex
+	names []string
+}
+
+func (t *nameTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.names = append(t.names, name)
+	return ctx, t
+}
+
+func (t *nameTracer) End(error) {}
+
+func TestChildSpans(t *testing.T) {
+	ok := NoErr(func() {})
+	group := func(ctx context.Context) error {
+		g := NewGroup(CollectAll, 0)
+		g.Go(ok)
+		g.Go(ok)
+		return g.Task().Run(ctx)
+	}
+	cases := []struct {
+		kind string
+		task Task
+	}{
+		{"wait", Wait(ok, ok)},
+		{"wait", WaitN(1, ok, ok)},
+		{"skip", SkipN(1, ok, ok)},
+		{"first", FirstWait(ok, ok)},
+		{"quorum", Quorum(2, ok, ok)},
+		{"group", group},
+		{"hedge", NoCtx(func() error { return errors.New("x") }).Hedge(0, 1)},
+	}
+
+	for _, c := range cases {
+		tr := &nameTracer{}
+		c.task.Run(WithTracer(context.Background(), tr))
+		slices.Sort(tr.names)
+		expect := []string{c.kind + "#0", c.kind + "#1"}
+		if !slices.Equal(tr.names, expect) {
+			t.Errorf("expected %q, got %q", expect, tr.names)
+		}
+	}
+}
+
+func TestNamedPanic(t *testing.T) {
+	var logs []string
+	ctx := WithTracer(context.Background(), logTracer{"a", &logs})
+
+	err := NoErr(func() { panic("boom") }).Named("t").Recover().Run(ctx)
+	var p *PanicError
+	if !errors.As(err, &p) {
+		t.Fatal("expected PanicError, got", err)
+	}
+	expect := []string{"a start t", "a end recovered from panic: boom"}
+	if !slices.Equal(logs, expect) {
+		t.Fatalf("expected %q, got %q", expect, logs)
+	}
+}
@@ -0,0 +1,14 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package tracing provides a [task.Tracer] which records spans in memory, and
+// exports them in Chrome trace-event format.
+//
+// The exported file can be viewed in chrome://tracing or https://ui.perfetto.dev:
+//
+//	rec := tracing.NewRecorder()
+//	ctx := task.WithTracer(context.Background(), rec)
+//	err := myTask.Named("my task").Run(ctx)
+//	rec.WriteChromeTrace(file)
+package tracing
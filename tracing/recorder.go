@@ -0,0 +1,188 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/raohwork/task"
+)
+
+// Record is a finished span.
+type Record struct {
+	ID     uint64
+	Parent uint64 // 0 if it is a root span
+	Name   string
+	Start  time.Time
+	Dur    time.Duration
+	Err    error
+	// Lane is an integer to arrange spans without overlapping, so spans in same
+	// lane are either nested or sequential. It is exported as thread id.
+	Lane int
+}
+
+// Recorder is a [task.Tracer] which records finished spans in memory. It is safe
+// for concurrent use.
+//
+// Spans are timed using the [task.Clock] carried by context.
+type Recorder struct {
+	lock    sync.Mutex
+	lastID  uint64
+	lanes   int
+	free    []int
+	records []Record
+}
+
+// NewRecorder creates a Recorder.
+func NewRecorder() *Recorder { return &Recorder{} }
+
+type spanKey struct{}
+
+type span struct {
+	r        *Recorder
+	clock    task.Clock
+	parent   *span
+	rec      Record
+	borrowed bool // using lane of parent
+	busy     bool // lane is used by a child
+}
+
+// Start implements [task.Tracer].
+func (r *Recorder) Start(ctx context.Context, name string) (context.Context, task.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*span)
+	if parent != nil && parent.r != r {
+		parent = nil
+	}
+	clock := task.ClockFrom(ctx)
+	s := &span{r: r, clock: clock, parent: parent}
+
+	r.lock.Lock()
+	r.lastID++
+	s.rec.ID = r.lastID
+	if parent != nil {
+		s.rec.Parent = parent.rec.ID
+		if !parent.busy {
+			parent.busy, s.borrowed = true, true
+			s.rec.Lane = parent.rec.Lane
+		}
+	}
+	if !s.borrowed {
+		s.rec.Lane = r.lane()
+	}
+	r.lock.Unlock()
+
+	s.rec.Name = name
+	s.rec.Start = clock.Now()
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// lane allocates a lane, must be called with lock held.
+func (r *Recorder) lane() (ret int) {
+	if l := len(r.free); l > 0 {
+		ret = r.free[l-1]
+		r.free = r.free[:l-1]
+		return
+	}
+	r.lanes++
+	return r.lanes
+}
+
+// End implements [task.Span].
+func (s *span) End(err error) {
+	s.rec.Dur = s.clock.Since(s.rec.Start)
+	s.rec.Err = err
+
+	r := s.r
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	switch {
+	case s.borrowed:
+		s.parent.busy = false
+	case !s.busy:
+		// lane is still used by a child if busy
+		r.free = append(r.free, s.rec.Lane)
+	}
+	r.records = append(r.records, s.rec)
+}
+
+// Records returns finished spans sorted by start time.
+func (r *Recorder) Records() []Record {
+	r.lock.Lock()
+	ret := make([]Record, len(r.records))
+	copy(ret, r.records)
+	r.lock.Unlock()
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Start.Before(ret[j].Start)
+	})
+	return ret
+}
+
+// Reset clears finished spans.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.records = nil
+}
+
+type traceEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat"`
+	Ph   string         `json:"ph"`
+	Ts   float64        `json:"ts"`
+	Dur  float64        `json:"dur"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args map[string]any `json:"args"`
+}
+
+type traceFile struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace writes finished spans to w in Chrome trace-event format.
+// Timestamps are relative to the earliest span.
+func (r *Recorder) WriteChromeTrace(w io.Writer) error {
+	recs := r.Records()
+	f := traceFile{
+		TraceEvents:     make([]traceEvent, 0, len(recs)),
+		DisplayTimeUnit: "ms",
+	}
+	var base time.Time
+	if len(recs) > 0 {
+		base = recs[0].Start
+	}
+	for _, rec := range recs {
+		args := map[string]any{"id": rec.ID}
+		if rec.Parent != 0 {
+			args["parent"] = rec.Parent
+		}
+		if rec.Err != nil {
+			args["error"] = rec.Err.Error()
+		}
+		f.TraceEvents = append(f.TraceEvents, traceEvent{
+			Name: rec.Name,
+			Cat:  "task",
+			Ph:   "X",
+			Ts:   micro(rec.Start.Sub(base)),
+			Dur:  micro(rec.Dur),
+			Pid:  1,
+			Tid:  rec.Lane,
+			Args: args,
+		})
+	}
+
+	return json.NewEncoder(w).Encode(f)
+}
+
+func micro(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
@@ -0,0 +1,104 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raohwork/task"
+	"github.com/raohwork/task/deptask"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder()
+	ctx := task.WithTracer(context.Background(), rec)
+	errFail := errors.New("fail")
+	// ensures a and b are running at same time
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	leaf := func(err error) task.Task {
+		return func(_ context.Context) error {
+			barrier.Done()
+			barrier.Wait()
+			time.Sleep(10 * time.Millisecond)
+			return err
+		}
+	}
+
+	err := task.Wait(
+		leaf(nil).Named("a"),
+		leaf(errFail).Named("b"),
+	).Named("root").Run(ctx)
+	if err != errFail {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	recs := rec.Records()
+	byName := map[string]Record{}
+	for _, r := range recs {
+		byName[r.Name] = r
+	}
+	if len(recs) != 5 || len(byName) != 5 {
+		t.Fatalf("expected 5 spans, got %+v", recs)
+	}
+
+	root, w0, w1 := byName["root"], byName["wait#0"], byName["wait#1"]
+	if root.Parent != 0 || w0.Parent != root.ID || w1.Parent != root.ID {
+		t.Fatalf("unexpected parents: %+v", recs)
+	}
+	if byName["a"].Parent != w0.ID || byName["b"].Parent != w1.ID {
+		t.Fatalf("unexpected parents: %+v", recs)
+	}
+	if w0.Lane == w1.Lane || (w0.Lane != root.Lane && w1.Lane != root.Lane) {
+		t.Fatalf("unexpected lanes: %+v", recs)
+	}
+	if byName["b"].Err != errFail || root.Err != errFail || byName["a"].Err != nil {
+		t.Fatalf("unexpected errors: %+v", recs)
+	}
+	if root.Dur < byName["a"].Dur || byName["a"].Dur < 10*time.Millisecond {
+		t.Fatalf("unexpected durations: %+v", recs)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := rec.WriteChromeTrace(buf); err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	var f struct {
+		TraceEvents []map[string]any `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &f); err != nil {
+		t.Fatal("invalid json: ", err)
+	}
+	if len(f.TraceEvents) != 5 || f.TraceEvents[0]["name"] != "root" || f.TraceEvents[0]["ph"] != "X" {
+		t.Fatalf("unexpected events: %v", f.TraceEvents)
+	}
+}
+
+func TestRecorderDeptask(t *testing.T) {
+	rec := NewRecorder()
+	ctx := task.WithTracer(context.Background(), rec)
+	nop := task.NoErr(func() {})
+
+	r := deptask.New()
+	r.MustAdd("a", nop)
+	r.MustAdd("b", nop, "a")
+	if err := r.RunSomeSync(ctx); err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	recs := rec.Records()
+	if len(recs) != 2 || recs[0].Name != "a" || recs[1].Name != "b" {
+		t.Fatalf("unexpected spans: %+v", recs)
+	}
+	if recs[0].Lane != recs[1].Lane {
+		t.Fatalf("sequential spans should share lane: %+v", recs)
+	}
+}